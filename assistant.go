@@ -230,6 +230,14 @@ func (c *Client) ListAssistants(
 	return
 }
 
+// ListAssistantsPager returns a Pager over all assistants.
+func (c *Client) ListAssistantsPager(ctx context.Context, pagination Pagination) *Pager[Assistant] {
+	return newPager(ctx, pagination, func(ctx context.Context, page Pagination) ([]Assistant, bool, string, error) {
+		list, err := c.ListAssistants(ctx, page.Limit, page.Order, page.After, page.Before)
+		return list.Assistants, list.HasMore, derefString(list.LastID), err
+	})
+}
+
 // CreateAssistantFile creates a new assistant file.
 func (c *Client) CreateAssistantFile(
 	ctx context.Context,
@@ -1,11 +1,9 @@
 package openai
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"io"
-	"net/http"
 	"os"
 
 	utils "github.com/neospace-ai/go-openai/internal"
@@ -49,32 +47,41 @@ type AudioRequest struct {
 	Language               string // Only for transcription.
 	Format                 AudioResponseFormat
 	TimestampGranularities []TranscriptionTimestampGranularity // Only for transcription.
+
+	// Progress is called while the audio is uploaded.
+	Progress UploadProgressFunc
+}
+
+// TranscriptionSegment is a segment of a transcription, with its timestamps in seconds.
+type TranscriptionSegment struct {
+	ID               int     `json:"id"`
+	Seek             int     `json:"seek"`
+	Start            float64 `json:"start"`
+	End              float64 `json:"end"`
+	Text             string  `json:"text"`
+	Tokens           []int   `json:"tokens"`
+	Temperature      float64 `json:"temperature"`
+	AvgLogprob       float64 `json:"avg_logprob"`
+	CompressionRatio float64 `json:"compression_ratio"`
+	NoSpeechProb     float64 `json:"no_speech_prob"`
+	Transient        bool    `json:"transient"`
+}
+
+// TranscriptionWord is a word of a transcription, with its timestamps in seconds.
+type TranscriptionWord struct {
+	Word  string  `json:"word"`
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
 }
 
 // AudioResponse represents a response structure for audio API.
 type AudioResponse struct {
-	Task     string  `json:"task"`
-	Language string  `json:"language"`
-	Duration float64 `json:"duration"`
-	Segments []struct {
-		ID               int     `json:"id"`
-		Seek             int     `json:"seek"`
-		Start            float64 `json:"start"`
-		End              float64 `json:"end"`
-		Text             string  `json:"text"`
-		Tokens           []int   `json:"tokens"`
-		Temperature      float64 `json:"temperature"`
-		AvgLogprob       float64 `json:"avg_logprob"`
-		CompressionRatio float64 `json:"compression_ratio"`
-		NoSpeechProb     float64 `json:"no_speech_prob"`
-		Transient        bool    `json:"transient"`
-	} `json:"segments"`
-	Words []struct {
-		Word  string  `json:"word"`
-		Start float64 `json:"start"`
-		End   float64 `json:"end"`
-	} `json:"words"`
-	Text string `json:"text"`
+	Task     string                 `json:"task"`
+	Language string                 `json:"language"`
+	Duration float64                `json:"duration"`
+	Segments []TranscriptionSegment `json:"segments"`
+	Words    []TranscriptionWord    `json:"words"`
+	Text     string                 `json:"text"`
 
 	httpHeader
 }
@@ -114,25 +121,17 @@ func (c *Client) callAudioAPI(
 	request AudioRequest,
 	endpointSuffix string,
 ) (response AudioResponse, err error) {
-	var formBody bytes.Buffer
-	builder := c.createFormBuilder(&formBody)
-
-	if err = audioMultipartForm(request, builder); err != nil {
-		return AudioResponse{}, err
+	form := func(builder utils.FormBuilder) error {
+		return audioMultipartForm(request, builder)
 	}
 
 	urlSuffix := fmt.Sprintf("/audio/%s", endpointSuffix)
-	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix, request.Model),
-		withBody(&formBody), withContentType(builder.FormDataContentType()))
-	if err != nil {
-		return AudioResponse{}, err
-	}
-
+	url := c.fullURL(urlSuffix, request.Model)
 	if request.HasJSONResponse() {
-		err = c.sendRequest(req, &response)
+		err = c.sendMultipartRequest(ctx, url, request.Progress, form, &response)
 	} else {
 		var textResponse audioTextResponse
-		err = c.sendRequest(req, &textResponse)
+		err = c.sendMultipartRequest(ctx, url, request.Progress, form, &textResponse)
 		response = textResponse.ToAudioResponse()
 	}
 	if err != nil {
@@ -0,0 +1,335 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	defaultSubtitleMaxLineLength = 42
+	defaultSubtitleMaxLines      = 2
+)
+
+var (
+	ErrInvalidSubtitles  = errors.New("invalid subtitles")
+	ErrNoAudioTimestamps = errors.New("audio response has no segments or words")
+)
+
+var subtitleTag = regexp.MustCompile(`<[^>]*>`)
+
+// SubtitleOptions controls how the segments of a transcription are laid out as subtitle cues.
+type SubtitleOptions struct {
+	// MaxLineLength is the maximum number of characters of a line, 42 by default. Lines are broken
+	// between words, so a longer word gets a line of its own.
+	MaxLineLength int
+	// MaxLines is the maximum number of lines of a cue, 2 by default. Segments that need more lines
+	// are split into several cues, timed with the words of the response when it has them, or else in
+	// proportion to the length of the text.
+	MaxLines int
+}
+
+// ParseSRT parses the segments of a transcription in the SRT format, the text of an AudioResponse
+// requested with AudioResponseFormatSRT. The lines of a cue are joined with spaces and formatting
+// tags are removed.
+func ParseSRT(text string) ([]TranscriptionSegment, error) {
+	return parseSubtitles(text, false)
+}
+
+// ParseVTT parses the segments of a transcription in the WebVTT format, like ParseSRT. Comments,
+// styles, regions and cue settings are ignored.
+func ParseVTT(text string) ([]TranscriptionSegment, error) {
+	return parseSubtitles(text, true)
+}
+
+func parseSubtitles(text string, vtt bool) ([]TranscriptionSegment, error) {
+	text = strings.TrimPrefix(text, "\uFEFF")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	lines := strings.Split(strings.ReplaceAll(text, "\r", "\n"), "\n")
+
+	segments := []TranscriptionSegment{}
+	header := vtt
+	for first := 0; first < len(lines); {
+		if strings.TrimSpace(lines[first]) == "" {
+			first++
+			continue
+		}
+		last := first
+		for last < len(lines) && strings.TrimSpace(lines[last]) != "" {
+			last++
+		}
+		block := lines[first:last]
+		line := first + 1
+		first = last
+
+		if header {
+			if block[0] != "WEBVTT" && !strings.HasPrefix(block[0], "WEBVTT ") &&
+				!strings.HasPrefix(block[0], "WEBVTT\t") {
+				return nil, fmt.Errorf("%w: line %d: missing WEBVTT header", ErrInvalidSubtitles, line)
+			}
+			header = false
+			continue
+		}
+		if vtt && (block[0] == "NOTE" || block[0] == "STYLE" || block[0] == "REGION" ||
+			strings.HasPrefix(block[0], "NOTE ") || strings.HasPrefix(block[0], "NOTE\t")) {
+			continue
+		}
+
+		// The timing line may follow a cue identifier, the index of the cue in SRT.
+		if !strings.Contains(block[0], "-->") && len(block) > 1 {
+			block = block[1:]
+			line++
+		}
+		start, end, err := parseSubtitleTiming(block[0], vtt)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidSubtitles, line, err)
+		}
+
+		words := make([]string, 0, len(block)-1)
+		for _, l := range block[1:] {
+			l = subtitleTag.ReplaceAllString(l, "")
+			if vtt {
+				l = html.UnescapeString(l)
+			}
+			words = append(words, strings.Fields(l)...)
+		}
+		segments = append(segments, TranscriptionSegment{
+			ID:    len(segments),
+			Start: start,
+			End:   end,
+			Text:  strings.Join(words, " "),
+		})
+	}
+	if header {
+		return nil, fmt.Errorf("%w: missing WEBVTT header", ErrInvalidSubtitles)
+	}
+	return segments, nil
+}
+
+// parseSubtitleTiming parses the start and end of a cue, in seconds, from its timing line.
+func parseSubtitleTiming(line string, vtt bool) (start, end float64, err error) {
+	from, to, found := strings.Cut(line, "-->")
+	if !found {
+		return 0, 0, fmt.Errorf("expected a timing line, got %q", line)
+	}
+	// WebVTT cue settings follow the end of the cue.
+	to, _, _ = strings.Cut(strings.TrimSpace(to), " ")
+	to, _, _ = strings.Cut(to, "\t")
+
+	if start, err = parseSubtitleTimestamp(strings.TrimSpace(from), vtt); err != nil {
+		return 0, 0, err
+	}
+	if end, err = parseSubtitleTimestamp(to, vtt); err != nil {
+		return 0, 0, err
+	}
+	if end < start {
+		return 0, 0, fmt.Errorf("cue ends at %s before it starts", to)
+	}
+	return start, end, nil
+}
+
+// parseSubtitleTimestamp parses a timestamp, hh:mm:ss,ttt in SRT and [hh:]mm:ss.ttt in WebVTT,
+// into seconds.
+func parseSubtitleTimestamp(timestamp string, vtt bool) (float64, error) {
+	separator := ","
+	if vtt {
+		separator = "."
+	}
+	clock, millis, found := strings.Cut(timestamp, separator)
+	fields := strings.Split(clock, ":")
+	if !found || len(millis) != 3 || len(fields) < 2 || len(fields) > 3 || (!vtt && len(fields) != 3) {
+		return 0, fmt.Errorf("invalid timestamp %q", timestamp)
+	}
+
+	var seconds float64
+	for i, field := range append(fields, millis) {
+		n, err := strconv.ParseUint(field, 10, 32)
+		last := i == len(fields)
+		if err != nil || (i > 0 && !last && (len(field) != 2 || n > 59)) {
+			return 0, fmt.Errorf("invalid timestamp %q", timestamp)
+		}
+		if last {
+			seconds += float64(n) / 1000
+		} else {
+			seconds = seconds*60 + float64(n)
+		}
+	}
+	return seconds, nil
+}
+
+// SRT renders the segments of a verbose JSON response as SRT subtitles.
+func (r AudioResponse) SRT(options SubtitleOptions) (string, error) {
+	cues, err := r.subtitleCues(options)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for i, cue := range cues {
+		fmt.Fprintf(&b, "%d\n%s --> %s\n", i+1, formatSubtitleTimestamp(cue.start, ','),
+			formatSubtitleTimestamp(cue.end, ','))
+		for _, line := range cue.lines {
+			b.WriteString(line)
+			b.WriteByte('\n')
+		}
+		b.WriteByte('\n')
+	}
+	return b.String(), nil
+}
+
+// VTT renders the segments of a verbose JSON response as WebVTT subtitles.
+func (r AudioResponse) VTT(options SubtitleOptions) (string, error) {
+	cues, err := r.subtitleCues(options)
+	if err != nil {
+		return "", err
+	}
+	escaper := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	var b strings.Builder
+	b.WriteString("WEBVTT\n\n")
+	for _, cue := range cues {
+		fmt.Fprintf(&b, "%s --> %s\n", formatSubtitleTimestamp(cue.start, '.'), formatSubtitleTimestamp(cue.end, '.'))
+		for _, line := range cue.lines {
+			b.WriteString(escaper.Replace(line))
+			b.WriteByte('\n')
+		}
+		b.WriteByte('\n')
+	}
+	return b.String(), nil
+}
+
+// formatSubtitleTimestamp formats seconds as hh:mm:ss followed by the separator and milliseconds.
+func formatSubtitleTimestamp(seconds float64, separator byte) string {
+	millis := max(int64(math.Round(seconds*1000)), 0)
+	return fmt.Sprintf("%02d:%02d:%02d%c%03d",
+		millis/3600000, millis/60000%60, millis/1000%60, separator, millis%1000)
+}
+
+// subtitleCue is a cue of subtitles, with its timestamps in seconds.
+type subtitleCue struct {
+	start, end float64
+	lines      []string
+}
+
+// subtitleCues lays out the segments of the response as cues. A response with words but no
+// segments is laid out as a single segment.
+func (r AudioResponse) subtitleCues(options SubtitleOptions) ([]subtitleCue, error) {
+	maxLineLength := options.MaxLineLength
+	if maxLineLength <= 0 {
+		maxLineLength = defaultSubtitleMaxLineLength
+	}
+	maxLines := options.MaxLines
+	if maxLines <= 0 {
+		maxLines = defaultSubtitleMaxLines
+	}
+
+	segments := r.Segments
+	if len(segments) == 0 {
+		if len(r.Words) == 0 {
+			return nil, ErrNoAudioTimestamps
+		}
+		texts := make([]string, len(r.Words))
+		for i, word := range r.Words {
+			texts[i] = word.Word
+		}
+		segments = []TranscriptionSegment{{
+			Start: r.Words[0].Start,
+			End:   r.Words[len(r.Words)-1].End,
+			Text:  strings.Join(texts, " "),
+		}}
+	}
+
+	var (
+		cues []subtitleCue
+		next int
+	)
+	for _, segment := range segments {
+		// The words of the segment are those whose middle is within it.
+		for next < len(r.Words) && (r.Words[next].Start+r.Words[next].End)/2 < segment.Start {
+			next++
+		}
+		first := next
+		for next < len(r.Words) && (r.Words[next].Start+r.Words[next].End)/2 <= segment.End {
+			next++
+		}
+
+		words := strings.Fields(segment.Text)
+		if len(words) == 0 {
+			continue
+		}
+		starts, ends := subtitleWordTimes(segment, words, r.Words[first:next])
+		lines := wrapSubtitle(words, maxLineLength)
+		for i := 0; i < len(lines); i += maxLines {
+			group := lines[i:min(i+maxLines, len(lines))]
+			cue := subtitleCue{
+				start: starts[group[0][0]],
+				end:   ends[group[len(group)-1][1]-1],
+				lines: make([]string, len(group)),
+			}
+			if i == 0 {
+				cue.start = segment.Start
+			}
+			if i+maxLines >= len(lines) {
+				cue.end = segment.End
+			}
+			cue.end = max(cue.end, cue.start)
+			for j, line := range group {
+				cue.lines[j] = strings.Join(words[line[0]:line[1]], " ")
+			}
+			cues = append(cues, cue)
+		}
+	}
+	return cues, nil
+}
+
+// subtitleWordTimes returns the start and end of every word of a segment, taken from the timed words
+// when they match the words of the segment, or else in proportion to the length of the text.
+func subtitleWordTimes(
+	segment TranscriptionSegment,
+	words []string,
+	timed []TranscriptionWord,
+) (starts, ends []float64) {
+	starts, ends = make([]float64, len(words)), make([]float64, len(words))
+	if len(timed) == len(words) {
+		for i, word := range timed {
+			starts[i], ends[i] = word.Start, word.End
+		}
+		return starts, ends
+	}
+
+	total := len(words) - 1
+	for _, word := range words {
+		total += utf8.RuneCountInString(word)
+	}
+	duration := segment.End - segment.Start
+	position := 0
+	for i, word := range words {
+		starts[i] = segment.Start + duration*float64(position)/float64(total)
+		position += utf8.RuneCountInString(word)
+		ends[i] = segment.Start + duration*float64(position)/float64(total)
+		position++
+	}
+	return starts, ends
+}
+
+// wrapSubtitle breaks words into lines of at most maxLength characters, and returns the range of
+// words of every line.
+func wrapSubtitle(words []string, maxLength int) [][2]int {
+	var lines [][2]int
+	start, length := 0, 0
+	for i, word := range words {
+		n := utf8.RuneCountInString(word)
+		if i > start && length+1+n > maxLength {
+			lines = append(lines, [2]int{start, i})
+			start, length = i, 0
+		}
+		if i > start {
+			length++
+		}
+		length += n
+	}
+	return append(lines, [2]int{start, len(words)})
+}
@@ -0,0 +1,117 @@
+package openai_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/neospace-ai/go-openai"
+	"github.com/neospace-ai/go-openai/internal/test/checks"
+)
+
+func TestParseSRT(t *testing.T) {
+	segments, err := openai.ParseSRT("\uFEFF1\r\n00:00:00,000 --> 00:00:02,500\r\nHello <i>there</i>,\r\n" +
+		"general Kenobi.\r\n\r\n2\r\n01:02:03,040 --> 01:02:05,000\r\nBye.\r\n")
+	checks.NoError(t, err)
+	want := []openai.TranscriptionSegment{
+		{ID: 0, Start: 0, End: 2.5, Text: "Hello there, general Kenobi."},
+		{ID: 1, Start: 3723.04, End: 3725, Text: "Bye."},
+	}
+	if !reflect.DeepEqual(segments, want) {
+		t.Fatalf("got %+v, want %+v", segments, want)
+	}
+
+	for _, text := range []string{
+		"1\n00:00:00.000 --> 00:00:01.000\nWrong separator.\n",
+		"1\n00:00,000 --> 00:01,000\nMissing hours.\n",
+		"1\n00:00:02,000 --> 00:00:01,000\nBackwards.\n",
+		"1\nHello.\n",
+	} {
+		_, err = openai.ParseSRT(text)
+		checks.ErrorIs(t, err, openai.ErrInvalidSubtitles, text)
+	}
+}
+
+func TestParseVTT(t *testing.T) {
+	segments, err := openai.ParseVTT("WEBVTT - transcription\n\nNOTE a comment\nover two lines\n\n" +
+		"STYLE\n::cue { color: white }\n\nintro\n00:01.000 --> 00:02.000 align:start\n" +
+		"<v Speaker>Fish &amp; chips</v>\n\n00:00:02.000 --> 00:00:03.250\nPlease.\n")
+	checks.NoError(t, err)
+	want := []openai.TranscriptionSegment{
+		{ID: 0, Start: 1, End: 2, Text: "Fish & chips"},
+		{ID: 1, Start: 2, End: 3.25, Text: "Please."},
+	}
+	if !reflect.DeepEqual(segments, want) {
+		t.Fatalf("got %+v, want %+v", segments, want)
+	}
+
+	_, err = openai.ParseVTT("00:01.000 --> 00:02.000\nNo header.\n")
+	checks.ErrorIs(t, err, openai.ErrInvalidSubtitles, "missing header")
+	_, err = openai.ParseVTT("")
+	checks.ErrorIs(t, err, openai.ErrInvalidSubtitles, "empty")
+}
+
+func TestAudioResponseSRT(t *testing.T) {
+	response := openai.AudioResponse{
+		Segments: []openai.TranscriptionSegment{
+			{Start: 0, End: 4, Text: " One two three four five six seven eight"},
+			{Start: 4, End: 5.5, Text: " Nine."},
+		},
+	}
+
+	srt, err := response.SRT(openai.SubtitleOptions{MaxLineLength: 10, MaxLines: 1})
+	checks.NoError(t, err)
+	// Without words, the cues are timed in proportion to the length of the text.
+	want := "1\n00:00:00,000 --> 00:00:00,718\nOne two\n\n" +
+		"2\n00:00:00,821 --> 00:00:01,846\nthree four\n\n" +
+		"3\n00:00:01,949 --> 00:00:02,769\nfive six\n\n" +
+		"4\n00:00:02,872 --> 00:00:03,385\nseven\n\n" +
+		"5\n00:00:03,487 --> 00:00:04,000\neight\n\n" +
+		"6\n00:00:04,000 --> 00:00:05,500\nNine.\n\n"
+	if srt != want {
+		t.Fatalf("got %q, want %q", srt, want)
+	}
+
+	response.Words = []openai.TranscriptionWord{
+		{Word: "One", Start: 0.1, End: 0.4}, {Word: "two", Start: 0.5, End: 0.8},
+		{Word: "three", Start: 1, End: 1.5}, {Word: "four", Start: 1.6, End: 2},
+		{Word: "five", Start: 2.2, End: 2.5}, {Word: "six", Start: 2.6, End: 2.9},
+		{Word: "seven", Start: 3, End: 3.4}, {Word: "eight", Start: 3.5, End: 3.9},
+		{Word: "Nine", Start: 4.2, End: 5},
+	}
+	srt, err = response.SRT(openai.SubtitleOptions{MaxLineLength: 16})
+	checks.NoError(t, err)
+	want = "1\n00:00:00,000 --> 00:00:02,900\nOne two three\nfour five six\n\n" +
+		"2\n00:00:03,000 --> 00:00:04,000\nseven eight\n\n" +
+		"3\n00:00:04,000 --> 00:00:05,500\nNine.\n\n"
+	if srt != want {
+		t.Fatalf("got %q, want %q", srt, want)
+	}
+
+	segments, err := openai.ParseSRT(srt)
+	checks.NoError(t, err)
+	if len(segments) != 3 || segments[0].Text != "One two three four five six" || segments[2].End != 5.5 {
+		t.Fatalf("unexpected segments %+v", segments)
+	}
+}
+
+func TestAudioResponseVTT(t *testing.T) {
+	// Without segments, the words are laid out as a single segment.
+	response := openai.AudioResponse{Words: []openai.TranscriptionWord{
+		{Word: "Fish", Start: 0.5, End: 0.9}, {Word: "&", Start: 1, End: 1.1}, {Word: "chips", Start: 1.2, End: 1.75},
+	}}
+	vtt, err := response.VTT(openai.SubtitleOptions{})
+	checks.NoError(t, err)
+	want := "WEBVTT\n\n00:00:00.500 --> 00:00:01.750\nFish &amp; chips\n\n"
+	if vtt != want {
+		t.Fatalf("got %q, want %q", vtt, want)
+	}
+
+	segments, err := openai.ParseVTT(vtt)
+	checks.NoError(t, err)
+	if len(segments) != 1 || segments[0].Text != "Fish & chips" {
+		t.Fatalf("unexpected segments %+v", segments)
+	}
+
+	_, err = openai.AudioResponse{Text: "No timestamps."}.VTT(openai.SubtitleOptions{})
+	checks.ErrorIs(t, err, openai.ErrNoAudioTimestamps, "no segments or words")
+}
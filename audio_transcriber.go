@@ -0,0 +1,333 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultTranscriptionMaxChunkDuration = 10 * time.Minute
+	defaultTranscriptionMaxChunkSize     = 24 << 20
+	defaultTranscriptionMinSilence       = 300 * time.Millisecond
+	defaultTranscriptionSilenceThreshold = 0.02
+	defaultTranscriptionConcurrency      = 4
+
+	// transcriptionWindow is the resolution of the silence detection.
+	transcriptionWindow = 10 * time.Millisecond
+	// transcriptionPromptRunes bounds the text of the previous chunk passed as prompt. The model
+	// only considers the last 224 tokens of a prompt.
+	transcriptionPromptRunes = 800
+	// transcriptionSeekRate is the number of seek units per second of the segments.
+	transcriptionSeekRate = 100
+)
+
+var ErrTranscriptionFormat = errors.New("response format cannot be stitched")
+
+// ChunkedTranscriber transcribes audio of any length. It splits the audio into chunks the API
+// accepts, preferably at silences, transcribes the chunks concurrently and stitches the
+// transcriptions into one, with the timestamps of segments and words relative to the whole audio.
+//
+// The chunks are divided into Concurrency runs of consecutive chunks. The chunks of a run are
+// transcribed one after the other, each with the end of the text of the previous one as prompt,
+// so that the transcription stays consistent across chunk boundaries.
+type ChunkedTranscriber struct {
+	client *Client
+
+	// MaxChunkDuration and MaxChunkSize bound every chunk. The API accepts files of up to 25 MB.
+	MaxChunkDuration time.Duration
+	MaxChunkSize     int
+	// A chunk is split at the longest silence in its second half, a stretch of at least MinSilence
+	// whose level stays under SilenceThreshold, relative to full scale. Without such a silence, it
+	// is split at its quietest point.
+	MinSilence       time.Duration
+	SilenceThreshold float64
+	// Concurrency is the maximum number of chunks transcribed at the same time.
+	Concurrency int
+}
+
+// NewChunkedTranscriber creates a ChunkedTranscriber with chunks of up to ten minutes under the size
+// limit of the API.
+func NewChunkedTranscriber(client *Client) *ChunkedTranscriber {
+	return &ChunkedTranscriber{
+		client:           client,
+		MaxChunkDuration: defaultTranscriptionMaxChunkDuration,
+		MaxChunkSize:     defaultTranscriptionMaxChunkSize,
+		MinSilence:       defaultTranscriptionMinSilence,
+		SilenceThreshold: defaultTranscriptionSilenceThreshold,
+		Concurrency:      defaultTranscriptionConcurrency,
+	}
+}
+
+// Transcribe transcribes the WAV audio of request.Reader, or of the file at request.FilePath. The
+// audio is read into memory. Only the JSON, verbose JSON and text response formats are supported,
+// and request.Progress is ignored.
+func (t *ChunkedTranscriber) Transcribe(ctx context.Context, request AudioRequest) (AudioResponse, error) {
+	data, err := readAudio(request)
+	if err != nil {
+		return AudioResponse{}, err
+	}
+	format, samples, err := parseWAV(data)
+	if err != nil {
+		return AudioResponse{}, err
+	}
+	return t.transcribe(ctx, request, format, samples)
+}
+
+// TranscribePCM transcribes raw audio of the given format, like Transcribe.
+func (t *ChunkedTranscriber) TranscribePCM(
+	ctx context.Context,
+	request AudioRequest,
+	format PCMFormat,
+) (AudioResponse, error) {
+	if err := format.validate(); err != nil {
+		return AudioResponse{}, err
+	}
+	samples, err := readAudio(request)
+	if err != nil {
+		return AudioResponse{}, err
+	}
+	return t.transcribe(ctx, request, format, samples[:len(samples)-len(samples)%format.FrameSize()])
+}
+
+func readAudio(request AudioRequest) ([]byte, error) {
+	if request.Reader != nil {
+		return io.ReadAll(request.Reader)
+	}
+	return os.ReadFile(request.FilePath)
+}
+
+func (t *ChunkedTranscriber) transcribe(
+	ctx context.Context,
+	request AudioRequest,
+	format PCMFormat,
+	samples []byte,
+) (AudioResponse, error) {
+	if !request.HasJSONResponse() && request.Format != AudioResponseFormatText {
+		return AudioResponse{}, fmt.Errorf("%w: %s", ErrTranscriptionFormat, request.Format)
+	}
+
+	chunks := t.split(format, samples)
+	responses, err := t.transcribeChunks(ctx, request, format, samples, chunks)
+	if err != nil {
+		return AudioResponse{}, err
+	}
+
+	frameSize := format.FrameSize()
+	offsets := make([]float64, len(chunks))
+	for i, chunk := range chunks {
+		offsets[i] = format.Duration(chunk.start * frameSize).Seconds()
+	}
+	return stitchTranscriptions(responses, offsets, format.Duration(len(samples)).Seconds()), nil
+}
+
+// audioChunk is a range of frames of the audio.
+type audioChunk struct {
+	start, end int
+}
+
+// split divides the frames of the audio into chunks within the limits.
+func (t *ChunkedTranscriber) split(format PCMFormat, samples []byte) []audioChunk {
+	frameSize := format.FrameSize()
+	frames := len(samples) / frameSize
+
+	maxDuration := t.MaxChunkDuration
+	if maxDuration <= 0 {
+		maxDuration = defaultTranscriptionMaxChunkDuration
+	}
+	maxSize := t.MaxChunkSize
+	if maxSize <= 0 {
+		maxSize = defaultTranscriptionMaxChunkSize
+	}
+	maxFrames := min(int(maxDuration.Seconds()*float64(format.SampleRate)), (maxSize-wavHeaderSize)/frameSize)
+	maxFrames = max(maxFrames, 2)
+	if frames <= maxFrames {
+		return []audioChunk{{start: 0, end: frames}}
+	}
+
+	window := max(int(transcriptionWindow.Seconds()*float64(format.SampleRate)), 1)
+	levels := audioLevels(format, samples, window)
+	minSilence := max(int(t.MinSilence/transcriptionWindow), 1)
+
+	var chunks []audioChunk
+	start := 0
+	for frames-start > maxFrames {
+		end := start + maxFrames
+		split := quietestFrame(levels, (start+maxFrames/2+window-1)/window, end/window, window, minSilence,
+			t.SilenceThreshold)
+		if split <= start || split > end {
+			split = end
+		}
+		chunks = append(chunks, audioChunk{start: start, end: split})
+		start = split
+	}
+	return append(chunks, audioChunk{start: start, end: frames})
+}
+
+// audioLevels returns the root mean square of the samples of every window of frames.
+func audioLevels(format PCMFormat, samples []byte, window int) []float64 {
+	frameSize := format.FrameSize()
+	frames := len(samples) / frameSize
+	levels := make([]float64, (frames+window-1)/window)
+	for w := range levels {
+		var sum float64
+		first, last := w*window, min((w+1)*window, frames)
+		for frame := first; frame < last; frame++ {
+			for channel := 0; channel < format.Channels; channel++ {
+				s := format.sample(samples[frame*frameSize:], channel)
+				sum += s * s
+			}
+		}
+		levels[w] = math.Sqrt(sum / float64((last-first)*format.Channels))
+	}
+	return levels
+}
+
+// quietestFrame returns the frame to split at among the windows from first to last, excluded: the
+// middle of the longest run of at least minSilence windows under threshold, or else the middle of
+// the quietest window. Later frames win ties, so that chunks are as long as possible. It returns
+// -1 if there is no window in the range.
+func quietestFrame(levels []float64, first, last, window, minSilence int, threshold float64) int {
+	last = min(last, len(levels))
+	if first >= last {
+		return -1
+	}
+
+	bestRun, bestRunEnd := 0, 0
+	quietest := first
+	run := 0
+	for w := first; w < last; w++ {
+		if levels[w] <= levels[quietest] {
+			quietest = w
+		}
+		if levels[w] < threshold {
+			run++
+			if run >= bestRun {
+				bestRun, bestRunEnd = run, w+1
+			}
+		} else {
+			run = 0
+		}
+	}
+
+	if bestRun >= minSilence {
+		return (bestRunEnd-bestRun)*window + bestRun*window/2
+	}
+	return quietest*window + window/2
+}
+
+// transcribeChunks transcribes the chunks in Concurrency runs of consecutive chunks, and returns
+// the transcriptions in order.
+func (t *ChunkedTranscriber) transcribeChunks(
+	ctx context.Context,
+	request AudioRequest,
+	format PCMFormat,
+	samples []byte,
+	chunks []audioChunk,
+) ([]AudioResponse, error) {
+	runs := min(max(t.Concurrency, 1), len(chunks))
+	responses := make([]AudioResponse, len(chunks))
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr == nil {
+			firstErr = err
+			cancel()
+		}
+	}
+
+	frameSize := format.FrameSize()
+	for run := 0; run < runs; run++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			previous := ""
+			for i := run * len(chunks) / runs; i < (run+1)*len(chunks)/runs; i++ {
+				data := samples[chunks[i].start*frameSize : chunks[i].end*frameSize]
+				wav := append(appendWAVHeader(make([]byte, 0, wavHeaderSize+len(data)), format, len(data)), data...)
+
+				chunkRequest := request
+				chunkRequest.Reader = bytes.NewReader(wav)
+				chunkRequest.FilePath = fmt.Sprintf("chunk-%d.wav", i)
+				chunkRequest.Prompt = transcriptionPrompt(request.Prompt, previous)
+				chunkRequest.Progress = nil
+				response, err := t.client.CreateTranscription(runCtx, chunkRequest)
+				if err != nil {
+					fail(fmt.Errorf("transcribing chunk %d: %w", i, err))
+					return
+				}
+				responses[i] = response
+				previous = response.Text
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return responses, nil
+}
+
+// transcriptionPrompt returns the prompt of a chunk: the prompt of the request followed by the end
+// of the text of the previous chunk.
+func transcriptionPrompt(prompt, previous string) string {
+	previous = strings.TrimSpace(previous)
+	if runes := []rune(previous); len(runes) > transcriptionPromptRunes {
+		previous = string(runes[len(runes)-transcriptionPromptRunes:])
+	}
+	return strings.TrimSpace(prompt + " " + previous)
+}
+
+// stitchTranscriptions joins the transcriptions of chunks starting at the given offsets, in seconds.
+func stitchTranscriptions(responses []AudioResponse, offsets []float64, duration float64) AudioResponse {
+	var (
+		stitched AudioResponse
+		texts    []string
+	)
+	for i, response := range responses {
+		if i == 0 {
+			stitched.Task = response.Task
+			stitched.Language = response.Language
+			stitched.httpHeader = response.httpHeader
+		}
+
+		offset := offsets[i]
+		for _, segment := range response.Segments {
+			segment.ID = len(stitched.Segments)
+			segment.Seek += int(math.Round(offset * transcriptionSeekRate))
+			segment.Start += offset
+			segment.End += offset
+			stitched.Segments = append(stitched.Segments, segment)
+		}
+		for _, word := range response.Words {
+			word.Start += offset
+			word.End += offset
+			stitched.Words = append(stitched.Words, word)
+		}
+		if text := strings.TrimSpace(response.Text); text != "" {
+			texts = append(texts, text)
+		}
+	}
+	stitched.Text = strings.Join(texts, " ")
+	stitched.Duration = duration
+	return stitched
+}
@@ -0,0 +1,229 @@
+package openai_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/neospace-ai/go-openai"
+	"github.com/neospace-ai/go-openai/internal/test"
+	"github.com/neospace-ai/go-openai/internal/test/checks"
+)
+
+const testSampleRate = 8000
+
+// tonePCM returns 16-bit mono samples alternating between a tone and silence, switching at the
+// given times in seconds.
+func tonePCM(duration float64, switches ...float64) []byte {
+	frames := int(duration * testSampleRate)
+	data := make([]byte, 2*frames)
+	for i := 0; i < frames; i++ {
+		t := float64(i) / testSampleRate
+		on := true
+		for _, s := range switches {
+			if t >= s {
+				on = !on
+			}
+		}
+		if on {
+			sample := int16(0.5 * math.MaxInt16 * math.Sin(2*math.Pi*440*t))
+			binary.LittleEndian.PutUint16(data[2*i:], uint16(sample))
+		}
+	}
+	return data
+}
+
+// testWAV wraps 16-bit mono samples in a WAV file, with a LIST chunk of odd size before the data.
+func testWAV(samples []byte) []byte {
+	var b bytes.Buffer
+	write := func(v any) { _ = binary.Write(&b, binary.LittleEndian, v) }
+	b.WriteString("RIFF")
+	write(uint32(4 + 24 + 8 + 4 + 8 + len(samples)))
+	b.WriteString("WAVEfmt ")
+	write(uint32(16))
+	write([]uint16{1, 1})
+	write([]uint32{testSampleRate, 2 * testSampleRate})
+	write([]uint16{2, 16})
+	b.WriteString("LIST")
+	write(uint32(3))
+	b.WriteString("abc\x00")
+	b.WriteString("data")
+	write(uint32(len(samples)))
+	b.Write(samples)
+	return b.Bytes()
+}
+
+// transcriptionServer transcribes a chunk named chunk-N.wav as "chunk N", with a segment over the
+// whole chunk and a word from 0.1 to 0.2 seconds.
+type transcriptionServer struct {
+	mu        sync.Mutex
+	prompts   map[int]string
+	durations map[int]float64
+	failChunk int
+}
+
+func newTranscriptionServer() *transcriptionServer {
+	return &transcriptionServer{prompts: make(map[int]string), durations: make(map[int]float64), failChunk: -1}
+}
+
+func (s *transcriptionServer) register(t *testing.T, server *test.ServerTest) {
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("file")
+		checks.NoError(t, err, "FormFile error")
+		data, err := io.ReadAll(file)
+		checks.NoError(t, err, "ReadAll error")
+
+		var index int
+		_, err = fmt.Sscanf(header.Filename, "chunk-%d.wav", &index)
+		checks.NoError(t, err, "unexpected file name "+header.Filename)
+		if string(data[:4]) != "RIFF" || string(data[36:40]) != "data" {
+			t.Errorf("chunk %d is not a WAV file", index)
+		}
+		duration := float64(len(data)-44) / 2 / testSampleRate
+		if index == s.failChunk {
+			http.Error(w, `{"error":{"message":"boom"}}`, http.StatusInternalServerError)
+			return
+		}
+
+		s.mu.Lock()
+		s.prompts[index] = r.FormValue("prompt")
+		s.durations[index] = duration
+		s.mu.Unlock()
+
+		text := fmt.Sprintf("chunk %d", index)
+		if r.FormValue("response_format") == string(openai.AudioResponseFormatText) {
+			fmt.Fprint(w, text)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"task":     "transcribe",
+			"language": "english",
+			"duration": duration,
+			"text":     " " + text,
+			"segments": []map[string]any{{"id": 0, "seek": 0, "start": 0, "end": duration, "text": text}},
+			"words":    []map[string]any{{"word": "chunk", "start": 0.1, "end": 0.2}},
+		})
+	})
+}
+
+func TestChunkedTranscriber(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	transcriptions := newTranscriptionServer()
+	transcriptions.register(t, server)
+
+	transcriber := openai.NewChunkedTranscriber(client)
+	transcriber.MaxChunkDuration = 3 * time.Second
+	transcriber.MinSilence = 200 * time.Millisecond
+	transcriber.Concurrency = 2
+	wav := testWAV(tonePCM(7, 2, 2.5, 5, 5.5))
+	response, err := transcriber.Transcribe(context.Background(), openai.AudioRequest{
+		Model:                  openai.Whisper1,
+		Reader:                 bytes.NewReader(wav),
+		FilePath:               "long.wav",
+		Prompt:                 "Glossary.",
+		Format:                 openai.AudioResponseFormatVerboseJSON,
+		TimestampGranularities: []openai.TranscriptionTimestampGranularity{openai.TranscriptionTimestampGranularityWord},
+	})
+	checks.NoError(t, err, "Transcribe error")
+
+	wantDurations := map[int]float64{0: 2.25, 1: 2.875, 2: 1.875}
+	if fmt.Sprint(transcriptions.durations) != fmt.Sprint(wantDurations) {
+		t.Errorf("chunk durations = %v, want %v", transcriptions.durations, wantDurations)
+	}
+	// The chunks are transcribed in two runs, chunk 0 then chunks 1 and 2.
+	wantPrompts := map[int]string{0: "Glossary.", 1: "Glossary.", 2: "Glossary. chunk 1"}
+	if fmt.Sprint(transcriptions.prompts) != fmt.Sprint(wantPrompts) {
+		t.Errorf("prompts = %v, want %v", transcriptions.prompts, wantPrompts)
+	}
+
+	if response.Text != "chunk 0 chunk 1 chunk 2" || response.Duration != 7 || response.Language != "english" {
+		t.Errorf("response = %q, %v s in %s, want 3 chunks over 7 s in english",
+			response.Text, response.Duration, response.Language)
+	}
+	wantStarts := []float64{0, 2.25, 5.125}
+	if len(response.Segments) != 3 || len(response.Words) != 3 {
+		t.Fatalf("got %d segments and %d words, want 3 each", len(response.Segments), len(response.Words))
+	}
+	for i, start := range wantStarts {
+		segment, word := response.Segments[i], response.Words[i]
+		if segment.ID != i || segment.Start != start || segment.End != start+wantDurations[i] ||
+			segment.Seek != int(math.Round(start*100)) {
+			t.Errorf("segment %d = %+v, want it from %v to %v", i, segment, start, start+wantDurations[i])
+		}
+		if math.Abs(word.Start-(start+0.1)) > 1e-9 || math.Abs(word.End-(start+0.2)) > 1e-9 {
+			t.Errorf("word %d = %+v, want it from %v", i, word, start+0.1)
+		}
+	}
+}
+
+func TestChunkedTranscriberWithoutSilence(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	transcriptions := newTranscriptionServer()
+	transcriptions.register(t, server)
+
+	transcriber := openai.NewChunkedTranscriber(client)
+	transcriber.MaxChunkSize = 44 + 2*testSampleRate*3
+	response, err := transcriber.TranscribePCM(context.Background(), openai.AudioRequest{
+		Model:  openai.Whisper1,
+		Reader: bytes.NewReader(tonePCM(7)),
+		Format: openai.AudioResponseFormatText,
+	}, openai.PCMFormat{SampleRate: testSampleRate, Channels: 1, BitsPerSample: 16})
+	checks.NoError(t, err, "TranscribePCM error")
+
+	var total float64
+	for i, duration := range transcriptions.durations {
+		if duration > 3 || duration < 1.5 && i < len(transcriptions.durations)-1 {
+			t.Errorf("chunk %d lasts %v s, want between 1.5 and 3", i, duration)
+		}
+		total += duration
+	}
+	if math.Abs(total-7) > 1e-9 || len(transcriptions.durations) != 3 {
+		t.Errorf("%d chunks last %v s, want 3 chunks over 7 s", len(transcriptions.durations), total)
+	}
+	if response.Text != "chunk 0 chunk 1 chunk 2" || response.Duration != 7 {
+		t.Errorf("response = %q over %v s", response.Text, response.Duration)
+	}
+}
+
+func TestChunkedTranscriberErrors(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	transcriptions := newTranscriptionServer()
+	transcriptions.failChunk = 1
+	transcriptions.register(t, server)
+
+	transcriber := openai.NewChunkedTranscriber(client)
+	transcriber.MaxChunkDuration = 3 * time.Second
+	request := openai.AudioRequest{Model: openai.Whisper1, Reader: bytes.NewReader(testWAV(tonePCM(7)))}
+	_, err := transcriber.Transcribe(context.Background(), request)
+	checks.HasError(t, err, "Transcribe should fail when a chunk fails")
+	if !strings.Contains(err.Error(), "chunk 1") {
+		t.Errorf("error = %v, want it to name chunk 1", err)
+	}
+
+	request.Reader = strings.NewReader("not a wav file")
+	_, err = transcriber.Transcribe(context.Background(), request)
+	checks.ErrorIs(t, err, openai.ErrUnsupportedAudio, "Transcribe should fail on invalid audio")
+
+	request.Reader = bytes.NewReader(testWAV(tonePCM(1)))
+	request.Format = openai.AudioResponseFormatSRT
+	_, err = transcriber.Transcribe(context.Background(), request)
+	checks.ErrorIs(t, err, openai.ErrTranscriptionFormat, "Transcribe should fail on SRT")
+
+	_, err = transcriber.TranscribePCM(context.Background(), request, openai.PCMFormat{SampleRate: 8000, Channels: 1})
+	if !errors.Is(err, openai.ErrUnsupportedAudio) {
+		t.Errorf("TranscribePCM error = %v, want ErrUnsupportedAudio", err)
+	}
+}
@@ -17,10 +17,11 @@ const (
 	BatchEndpointChatCompletions BatchEndpoint = "/v1/chat/completions"
 	BatchEndpointCompletions     BatchEndpoint = "/v1/completions"
 	BatchEndpointEmbeddings      BatchEndpoint = "/v1/embeddings"
+	BatchEndpointSupervisor      BatchEndpoint = "/v1/supervisor"
 )
 
 type BatchLineItem interface {
-	MarshalBatchLineItem() []byte
+	MarshalBatchLineItem() ([]byte, error)
 }
 
 type BatchChatCompletionRequest struct {
@@ -30,9 +31,8 @@ type BatchChatCompletionRequest struct {
 	URL      BatchEndpoint         `json:"url"`
 }
 
-func (r BatchChatCompletionRequest) MarshalBatchLineItem() []byte {
-	marshal, _ := json.Marshal(r)
-	return marshal
+func (r BatchChatCompletionRequest) MarshalBatchLineItem() ([]byte, error) {
+	return json.Marshal(r)
 }
 
 type BatchCompletionRequest struct {
@@ -42,9 +42,8 @@ type BatchCompletionRequest struct {
 	URL      BatchEndpoint     `json:"url"`
 }
 
-func (r BatchCompletionRequest) MarshalBatchLineItem() []byte {
-	marshal, _ := json.Marshal(r)
-	return marshal
+func (r BatchCompletionRequest) MarshalBatchLineItem() ([]byte, error) {
+	return json.Marshal(r)
 }
 
 type BatchEmbeddingRequest struct {
@@ -54,9 +53,30 @@ type BatchEmbeddingRequest struct {
 	URL      BatchEndpoint    `json:"url"`
 }
 
-func (r BatchEmbeddingRequest) MarshalBatchLineItem() []byte {
-	marshal, _ := json.Marshal(r)
-	return marshal
+func (r BatchEmbeddingRequest) MarshalBatchLineItem() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+// BatchSupervisorRequest is a batch line grading a task with the supervisor.
+// Its body is sent in the same format as CreateSupervisorCompletion.
+type BatchSupervisorRequest struct {
+	CustomID string            `json:"custom_id"`
+	Body     SupervisorRequest `json:"body"`
+	Method   string            `json:"method"`
+	URL      BatchEndpoint     `json:"url"`
+}
+
+func (r BatchSupervisorRequest) MarshalBatchLineItem() ([]byte, error) {
+	body, err := r.Body.neolangInput()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		CustomID string        `json:"custom_id"`
+		Body     neolangInput  `json:"body"`
+		Method   string        `json:"method"`
+		URL      BatchEndpoint `json:"url"`
+	}{r.CustomID, body, r.Method, r.URL})
 }
 
 type Batch struct {
@@ -90,6 +110,17 @@ type Batch struct {
 	Metadata         map[string]any     `json:"metadata"`
 }
 
+const (
+	BatchStatusValidating = "validating"
+	BatchStatusFailed     = "failed"
+	BatchStatusInProgress = "in_progress"
+	BatchStatusFinalizing = "finalizing"
+	BatchStatusCompleted  = "completed"
+	BatchStatusExpired    = "expired"
+	BatchStatusCancelling = "cancelling"
+	BatchStatusCancelled  = "cancelled"
+)
+
 type BatchRequestCounts struct {
 	Total     int `json:"total"`
 	Completed int `json:"completed"`
@@ -131,15 +162,19 @@ type UploadBatchFileRequest struct {
 	Lines    []BatchLineItem
 }
 
-func (r *UploadBatchFileRequest) MarshalJSONL() []byte {
+func (r *UploadBatchFileRequest) MarshalJSONL() ([]byte, error) {
 	buff := bytes.Buffer{}
 	for i, line := range r.Lines {
 		if i != 0 {
 			buff.Write([]byte("\n"))
 		}
-		buff.Write(line.MarshalBatchLineItem())
+		data, err := line.MarshalBatchLineItem()
+		if err != nil {
+			return nil, fmt.Errorf("batch line %d: %w", i, err)
+		}
+		buff.Write(data)
 	}
-	return buff.Bytes()
+	return buff.Bytes(), nil
 }
 
 func (r *UploadBatchFileRequest) AddChatCompletion(customerID string, body ChatCompletionRequest) {
@@ -160,23 +195,38 @@ func (r *UploadBatchFileRequest) AddCompletion(customerID string, body Completio
 	})
 }
 
-func (r *UploadBatchFileRequest) AddEmbedding(customerID string, body EmbeddingRequest) {
+// AddEmbedding adds an embedding line. The body may be an EmbeddingRequest,
+// EmbeddingRequestStrings or EmbeddingRequestTokens.
+func (r *UploadBatchFileRequest) AddEmbedding(customerID string, body EmbeddingRequestConverter) {
 	r.Lines = append(r.Lines, BatchEmbeddingRequest{
 		CustomID: customerID,
-		Body:     body,
+		Body:     body.Convert(),
 		Method:   "POST",
 		URL:      BatchEndpointEmbeddings,
 	})
 }
 
+func (r *UploadBatchFileRequest) AddSupervisor(customerID string, body SupervisorRequest) {
+	r.Lines = append(r.Lines, BatchSupervisorRequest{
+		CustomID: customerID,
+		Body:     body,
+		Method:   "POST",
+		URL:      BatchEndpointSupervisor,
+	})
+}
+
 // UploadBatchFile — upload batch file.
 func (c *Client) UploadBatchFile(ctx context.Context, request UploadBatchFileRequest) (File, error) {
 	if request.FileName == "" {
 		request.FileName = "@batchinput.jsonl"
 	}
+	data, err := request.MarshalJSONL()
+	if err != nil {
+		return File{}, err
+	}
 	return c.CreateFileBytes(ctx, FileBytesRequest{
 		Name:    request.FileName,
-		Bytes:   request.MarshalJSONL(),
+		Bytes:   data,
 		Purpose: PurposeBatch,
 	})
 }
@@ -269,3 +319,11 @@ func (c *Client) ListBatch(ctx context.Context, after *string, limit *int) (resp
 	err = c.sendRequest(req, &response)
 	return
 }
+
+// ListBatchPager returns a Pager over all batches. Only Limit and After of the pagination are used.
+func (c *Client) ListBatchPager(ctx context.Context, pagination Pagination) *Pager[Batch] {
+	return newPager(ctx, pagination, func(ctx context.Context, page Pagination) ([]Batch, bool, string, error) {
+		list, err := c.ListBatch(ctx, page.After, page.Limit)
+		return list.Data, list.HasMore, list.LastID, err
+	})
+}
@@ -0,0 +1,171 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+const defaultLocalBatchConcurrency = 8
+
+// LocalBatchExecutor emulates the Batch API on the client, for deployments without a /batches endpoint.
+// It sends every line of a batch to its endpoint and writes the results in the format of batch output
+// files, so that they can be consumed with NewBatchResultReader like the results of a real batch.
+type LocalBatchExecutor struct {
+	client *Client
+
+	// Concurrency is the maximum number of lines sent at the same time.
+	Concurrency int
+}
+
+// NewLocalBatchExecutor creates a LocalBatchExecutor with a default concurrency.
+func NewLocalBatchExecutor(client *Client) *LocalBatchExecutor {
+	return &LocalBatchExecutor{
+		client:      client,
+		Concurrency: defaultLocalBatchConcurrency,
+	}
+}
+
+type localBatchResponse struct {
+	StatusCode int    `json:"status_code"`
+	RequestID  string `json:"request_id"`
+	Body       any    `json:"body"`
+}
+
+type localBatchOutputLine struct {
+	ID       string              `json:"id"`
+	CustomID string              `json:"custom_id"`
+	Response *localBatchResponse `json:"response"`
+	Error    *APIError           `json:"error"`
+}
+
+// Execute runs the lines of the request and writes a batch output line for each of them, in the order
+// they complete. Successful lines are written to output, and failed lines to errorOutput, like the output
+// and error files of a batch. When errorOutput is nil, failed lines are written to output as well.
+//
+// When the context is done, no more lines are started and the context error is returned. Lines that were
+// not run have no output line.
+func (e *LocalBatchExecutor) Execute(
+	ctx context.Context,
+	request UploadBatchFileRequest,
+	output io.Writer,
+	errorOutput io.Writer,
+) (counts BatchRequestCounts, err error) {
+	if errorOutput == nil {
+		errorOutput = output
+	}
+	concurrency := e.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		writeErr error
+	)
+	sem := make(chan struct{}, concurrency)
+	counts.Total = len(request.Lines)
+
+lines:
+	for i, line := range request.Lines {
+		select {
+		case <-ctx.Done():
+			break lines
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result := e.execute(ctx, line)
+			result.ID = fmt.Sprintf("batch_req_%d", i)
+			failed := result.Error != nil || result.Response.StatusCode >= http.StatusBadRequest
+			data, marshalErr := json.Marshal(result)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if failed {
+				counts.Failed++
+			} else {
+				counts.Completed++
+			}
+			if writeErr != nil {
+				return
+			}
+			if marshalErr != nil {
+				writeErr = marshalErr
+				return
+			}
+			w := output
+			if failed {
+				w = errorOutput
+			}
+			_, writeErr = w.Write(append(data, '\n'))
+		}()
+	}
+	wg.Wait()
+
+	if err = ctx.Err(); err != nil {
+		return
+	}
+	err = writeErr
+	return
+}
+
+func (e *LocalBatchExecutor) execute(ctx context.Context, line BatchLineItem) localBatchOutputLine {
+	var (
+		customID string
+		body     any
+		header   http.Header
+		err      error
+	)
+	switch l := line.(type) {
+	case BatchChatCompletionRequest:
+		var response ChatCompletionResponse
+		response, err = e.client.CreateChatCompletion(ctx, l.Body)
+		customID, body, header = l.CustomID, response, response.Header()
+	case BatchCompletionRequest:
+		var response CompletionResponse
+		response, err = e.client.CreateCompletion(ctx, l.Body)
+		customID, body, header = l.CustomID, response, response.Header()
+	case BatchEmbeddingRequest:
+		var response EmbeddingResponse
+		response, err = e.client.CreateEmbeddings(ctx, l.Body)
+		customID, body, header = l.CustomID, response, response.Header()
+	case BatchSupervisorRequest:
+		var response SupervisorResponse
+		response, err = e.client.CreateSupervisorCompletion(ctx, l.Body)
+		customID, body, header = l.CustomID, response, response.Header()
+	default:
+		return localBatchOutputLine{
+			CustomID: batchLineCustomID(line),
+			Error:    &APIError{Code: "invalid_request", Message: fmt.Sprintf("unsupported batch line %T", line)},
+		}
+	}
+
+	result := localBatchOutputLine{CustomID: customID}
+	var apiErr *APIError
+	switch {
+	case err == nil:
+		result.Response = &localBatchResponse{
+			StatusCode: http.StatusOK,
+			RequestID:  header.Get("X-Request-Id"),
+			Body:       body,
+		}
+	case errors.As(err, &apiErr) && apiErr.HTTPStatusCode > 0:
+		result.Response = &localBatchResponse{
+			StatusCode: apiErr.HTTPStatusCode,
+			Body:       ErrorResponse{Error: apiErr},
+		}
+	default:
+		result.Error = &APIError{Message: err.Error()}
+	}
+	return result
+}
@@ -0,0 +1,85 @@
+package openai_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/neospace-ai/go-openai"
+	"github.com/neospace-ai/go-openai/internal/test/checks"
+)
+
+func TestLocalBatchExecutor(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		var request openai.ChatCompletionRequest
+		checks.NoError(t, json.NewDecoder(r.Body).Decode(&request), "Decode error")
+		content := request.Messages[0].Content
+		if content == "fail" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintln(w, `{"error":{"message":"bad request","type":"invalid_request_error"}}`)
+			return
+		}
+		w.Header().Set("X-Request-Id", "req_"+content)
+		fmt.Fprintf(w, `{"id":"chatcmpl","choices":[{"message":{"role":"assistant","content":%q}}]}`, content)
+	})
+
+	request := openai.UploadBatchFileRequest{}
+	for _, content := range []string{"one", "fail", "two"} {
+		request.AddChatCompletion("req-"+content, openai.ChatCompletionRequest{
+			Model:    openai.GPT4oMini,
+			Messages: []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: content}},
+		})
+	}
+
+	var output, errorOutput bytes.Buffer
+	executor := openai.NewLocalBatchExecutor(client)
+	executor.Concurrency = 2
+	counts, err := executor.Execute(context.Background(), request, &output, &errorOutput)
+	checks.NoErrorF(t, err, "Execute error")
+	if counts.Total != 3 || counts.Completed != 2 || counts.Failed != 1 {
+		t.Errorf("unexpected counts: %+v", counts)
+	}
+
+	reader := openai.NewBatchResultReader(&output, openai.BatchEndpointChatCompletions).JoinLines(request.Lines)
+	for {
+		result, recvErr := reader.Recv()
+		if errors.Is(recvErr, io.EOF) {
+			break
+		}
+		checks.NoErrorF(t, recvErr, "Recv error")
+		content := result.ChatCompletion.Choices[0].Message.Content
+		if result.CustomID != "req-"+content || result.RequestID != "req_"+content || result.Request == nil {
+			t.Errorf("unexpected result: %+v", result)
+		}
+	}
+	if unanswered := reader.Unanswered(); len(unanswered) != 1 {
+		t.Errorf("expected only the failed line to be missing from the output, got %d", len(unanswered))
+	}
+
+	errorReader := openai.NewBatchResultReader(&errorOutput, openai.BatchEndpointChatCompletions)
+	result, err := errorReader.Recv()
+	checks.NoErrorF(t, err, "Recv error")
+	if result.CustomID != "req-fail" || result.StatusCode != http.StatusBadRequest || result.Error == nil ||
+		result.Error.Message != "bad request" {
+		t.Errorf("unexpected failed result: %+v", result)
+	}
+}
+
+func TestLocalBatchExecutorCancelled(t *testing.T) {
+	client := openai.NewClient("")
+	request := openai.UploadBatchFileRequest{}
+	request.AddChatCompletion("req-1", openai.ChatCompletionRequest{Model: openai.GPT4oMini})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var output bytes.Buffer
+	_, err := openai.NewLocalBatchExecutor(client).Execute(ctx, request, &output, nil)
+	checks.ErrorIs(t, err, context.Canceled, "Execute did not return the context error")
+}
@@ -0,0 +1,251 @@
+package openai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+var ErrBatchNoResultFiles = errors.New("batch has no output or error file")
+
+// BatchResult is a line of a batch output or error file. Depending on the endpoint of the batch,
+// one of ChatCompletion, Completion, Embedding and Supervisor is set when the request succeeded.
+// Error is set when the request failed, either before reaching the model or with an error status code.
+type BatchResult struct {
+	ID         string
+	CustomID   string
+	StatusCode int
+	RequestID  string
+	// Body is the raw response body.
+	Body json.RawMessage
+
+	ChatCompletion *ChatCompletionResponse
+	Completion     *CompletionResponse
+	Embedding      *EmbeddingResponse
+	Supervisor     *SupervisorResponse
+
+	Error *APIError
+	// Request is the original line of the batch, set when the reader was joined with the input lines.
+	Request BatchLineItem
+}
+
+type batchOutputLine struct {
+	ID       string `json:"id"`
+	CustomID string `json:"custom_id"`
+	Response *struct {
+		StatusCode int             `json:"status_code"`
+		RequestID  string          `json:"request_id"`
+		Body       json.RawMessage `json:"body"`
+	} `json:"response"`
+	Error *APIError `json:"error"`
+}
+
+// BatchResultReader reads batch results line by line, so that outputs of any size can be consumed
+// without loading them into memory.
+type BatchResultReader struct {
+	ctx      context.Context
+	client   *Client
+	fileIDs  []string
+	endpoint BatchEndpoint
+
+	body   io.ReadCloser
+	reader *bufio.Reader
+
+	requests map[string]BatchLineItem
+	order    []string
+}
+
+// NewBatchResultReader creates a BatchResultReader over batch output JSONL, for example a file
+// downloaded earlier. The endpoint selects the type the response bodies are decoded to.
+func NewBatchResultReader(r io.Reader, endpoint BatchEndpoint) *BatchResultReader {
+	body, ok := r.(io.ReadCloser)
+	if !ok {
+		body = io.NopCloser(r)
+	}
+	return &BatchResultReader{
+		endpoint: endpoint,
+		body:     body,
+		reader:   bufio.NewReader(body),
+	}
+}
+
+// GetBatchResults returns a reader over the results of the batch: the lines of its output file,
+// followed by the lines of its error file. The files are downloaded while they are read.
+func (c *Client) GetBatchResults(ctx context.Context, batch Batch) (*BatchResultReader, error) {
+	var fileIDs []string
+	if batch.OutputFileID != nil && *batch.OutputFileID != "" {
+		fileIDs = append(fileIDs, *batch.OutputFileID)
+	}
+	if batch.ErrorFileID != nil && *batch.ErrorFileID != "" {
+		fileIDs = append(fileIDs, *batch.ErrorFileID)
+	}
+	if len(fileIDs) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrBatchNoResultFiles, batch.ID)
+	}
+	return &BatchResultReader{
+		ctx:      ctx,
+		client:   c,
+		fileIDs:  fileIDs,
+		endpoint: batch.Endpoint,
+	}, nil
+}
+
+// JoinLines makes Recv set the Request of each result to the line with the same custom_id,
+// usually the UploadBatchFileRequest.Lines the batch was created from.
+func (r *BatchResultReader) JoinLines(lines []BatchLineItem) *BatchResultReader {
+	r.requests = make(map[string]BatchLineItem, len(lines))
+	r.order = make([]string, 0, len(lines))
+	for _, line := range lines {
+		customID := batchLineCustomID(line)
+		r.requests[customID] = line
+		r.order = append(r.order, customID)
+	}
+	return r
+}
+
+// Unanswered returns the joined lines that no result was read for yet, in their original order.
+// After Recv returned io.EOF, these are the lines the batch did not process.
+func (r *BatchResultReader) Unanswered() []BatchLineItem {
+	var lines []BatchLineItem
+	for _, customID := range r.order {
+		if line, ok := r.requests[customID]; ok {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// Recv returns the next result, or io.EOF once all files are read.
+func (r *BatchResultReader) Recv() (result BatchResult, err error) {
+	line, err := r.nextLine()
+	if err != nil {
+		return
+	}
+
+	var output batchOutputLine
+	if err = json.Unmarshal(line, &output); err != nil {
+		return
+	}
+
+	result = BatchResult{
+		ID:       output.ID,
+		CustomID: output.CustomID,
+		Error:    output.Error,
+	}
+	if r.requests != nil {
+		result.Request = r.requests[output.CustomID]
+		delete(r.requests, output.CustomID)
+	}
+	if output.Response == nil {
+		return
+	}
+
+	result.StatusCode = output.Response.StatusCode
+	result.RequestID = output.Response.RequestID
+	result.Body = output.Response.Body
+	if result.StatusCode >= http.StatusBadRequest {
+		var errResponse ErrorResponse
+		if json.Unmarshal(result.Body, &errResponse) == nil && errResponse.Error != nil {
+			result.Error = errResponse.Error
+			result.Error.HTTPStatusCode = result.StatusCode
+		}
+		return
+	}
+
+	err = r.decodeBody(&result)
+	return
+}
+
+func (r *BatchResultReader) decodeBody(result *BatchResult) error {
+	var body any
+	switch r.endpoint {
+	case BatchEndpointChatCompletions:
+		result.ChatCompletion = &ChatCompletionResponse{}
+		body = result.ChatCompletion
+	case BatchEndpointCompletions:
+		result.Completion = &CompletionResponse{}
+		body = result.Completion
+	case BatchEndpointEmbeddings:
+		result.Embedding = &EmbeddingResponse{}
+		body = result.Embedding
+	case BatchEndpointSupervisor:
+		result.Supervisor = &SupervisorResponse{}
+		if err := json.Unmarshal(result.Body, result.Supervisor); err != nil {
+			return err
+		}
+		setChosenName(result.Supervisor)
+		return nil
+	default:
+		return nil
+	}
+	return json.Unmarshal(result.Body, body)
+}
+
+// nextLine returns the next non-empty line, opening the next file when the current one is exhausted.
+func (r *BatchResultReader) nextLine() ([]byte, error) {
+	for {
+		if r.reader == nil {
+			if len(r.fileIDs) == 0 {
+				return nil, io.EOF
+			}
+			content, err := r.client.GetFileContent(r.ctx, r.fileIDs[0])
+			if err != nil {
+				return nil, err
+			}
+			r.fileIDs = r.fileIDs[1:]
+			r.body = content
+			r.reader = bufio.NewReader(content)
+		}
+
+		line, err := r.reader.ReadBytes('\n')
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 {
+			return line, nil
+		}
+		if errors.Is(err, io.EOF) {
+			r.body.Close()
+			r.body, r.reader = nil, nil
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Close closes the file being read.
+func (r *BatchResultReader) Close() error {
+	r.fileIDs = nil
+	if r.body == nil {
+		return nil
+	}
+	return r.body.Close()
+}
+
+// batchLineCustomID returns the custom_id of a batch line.
+func batchLineCustomID(line BatchLineItem) string {
+	switch l := line.(type) {
+	case BatchChatCompletionRequest:
+		return l.CustomID
+	case BatchCompletionRequest:
+		return l.CustomID
+	case BatchEmbeddingRequest:
+		return l.CustomID
+	case BatchSupervisorRequest:
+		return l.CustomID
+	}
+	data, err := line.MarshalBatchLineItem()
+	if err != nil {
+		return ""
+	}
+	var decoded struct {
+		CustomID string `json:"custom_id"`
+	}
+	_ = json.Unmarshal(data, &decoded)
+	return decoded.CustomID
+}
@@ -0,0 +1,113 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/neospace-ai/go-openai"
+	"github.com/neospace-ai/go-openai/internal/test/checks"
+)
+
+func TestGetBatchResults(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/files/file-out/content", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, `{"id":"batch_req_1","custom_id":"req-1","response":{"status_code":200,"request_id":"r1",`+
+			`"body":{"id":"chatcmpl-1","object":"chat.completion",`+
+			`"choices":[{"message":{"role":"assistant","content":"Hi"}}]}},"error":null}`)
+		fmt.Fprintln(w, `{"id":"batch_req_2","custom_id":"req-2","response":{"status_code":429,"request_id":"r2",`+
+			`"body":{"error":{"message":"Rate limit","type":"requests"}}},"error":null}`)
+	})
+	server.RegisterHandler("/v1/files/file-err/content", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"id":"batch_req_3","custom_id":"req-3","response":null,`+
+			`"error":{"code":"invalid_request","message":"bad line"}}`)
+	})
+
+	lines := openai.UploadBatchFileRequest{}
+	for i := 1; i <= 4; i++ {
+		lines.AddChatCompletion(fmt.Sprintf("req-%d", i), openai.ChatCompletionRequest{Model: openai.GPT4oMini})
+	}
+
+	outputFileID, errorFileID := "file-out", "file-err"
+	reader, err := client.GetBatchResults(context.Background(), openai.Batch{
+		Endpoint:     openai.BatchEndpointChatCompletions,
+		OutputFileID: &outputFileID,
+		ErrorFileID:  &errorFileID,
+	})
+	checks.NoErrorF(t, err, "GetBatchResults error")
+	defer reader.Close()
+	reader.JoinLines(lines.Lines)
+
+	var results []openai.BatchResult
+	for {
+		result, recvErr := reader.Recv()
+		if errors.Is(recvErr, io.EOF) {
+			break
+		}
+		checks.NoErrorF(t, recvErr, "Recv error")
+		results = append(results, result)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	if results[0].ChatCompletion == nil || results[0].ChatCompletion.Choices[0].Message.Content != "Hi" {
+		t.Errorf("unexpected chat completion result: %+v", results[0])
+	}
+	request, ok := results[0].Request.(openai.BatchChatCompletionRequest)
+	if !ok || request.CustomID != "req-1" {
+		t.Errorf("result was not joined with its line: %+v", results[0].Request)
+	}
+	if results[1].Error == nil || results[1].Error.HTTPStatusCode != http.StatusTooManyRequests {
+		t.Errorf("unexpected failed result: %+v", results[1])
+	}
+	if results[2].Error == nil || results[2].Error.Code != "invalid_request" || results[2].StatusCode != 0 {
+		t.Errorf("unexpected error file result: %+v", results[2])
+	}
+
+	unanswered := reader.Unanswered()
+	if len(unanswered) != 1 || unanswered[0].(openai.BatchChatCompletionRequest).CustomID != "req-4" {
+		t.Errorf("unexpected unanswered lines: %+v", unanswered)
+	}
+}
+
+func TestGetBatchResultsNoFiles(t *testing.T) {
+	client := openai.NewClient("")
+	_, err := client.GetBatchResults(context.Background(), openai.Batch{ID: "batch_1"})
+	checks.ErrorIs(t, err, openai.ErrBatchNoResultFiles, "GetBatchResults did not return ErrBatchNoResultFiles")
+}
+
+func TestNewBatchResultReaderEmbeddings(t *testing.T) {
+	output := `{"custom_id":"emb-1","response":{"status_code":200,"body":{"object":"list",` +
+		`"data":[{"object":"embedding","embedding":[0.5],"index":0}]}}}` + "\n\n"
+	reader := openai.NewBatchResultReader(strings.NewReader(output), openai.BatchEndpointEmbeddings)
+
+	result, err := reader.Recv()
+	checks.NoErrorF(t, err, "Recv error")
+	if result.Embedding == nil || result.Embedding.Data[0].Embedding[0] != 0.5 {
+		t.Errorf("unexpected embedding result: %+v", result)
+	}
+	_, err = reader.Recv()
+	checks.ErrorIs(t, err, io.EOF, "Recv did not return io.EOF")
+}
+
+func TestBatchResultReaderSupervisor(t *testing.T) {
+	output := `{"custom_id":"req-1","response":{"status_code":200,"body":{"choices":[{"result":{"components":[` +
+		`{"name":"tone","available_scores":[{"token":1,"token_name":"good"}],"chosen":1}]}}]}}}`
+	reader := openai.NewBatchResultReader(strings.NewReader(output), openai.BatchEndpointSupervisor)
+
+	result, err := reader.Recv()
+	checks.NoErrorF(t, err, "Recv error")
+	if result.Supervisor == nil {
+		t.Fatalf("unexpected supervisor result: %+v", result)
+	}
+	chosenName := result.Supervisor.Choices[0].Result.Components[0].ChosenName
+	if chosenName == nil || *chosenName != "good" {
+		t.Errorf("chosen name was not set: %v", chosenName)
+	}
+}
@@ -0,0 +1,374 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultBatchMaxLines        = 50000
+	defaultBatchMaxBytes        = 200 << 20
+	defaultBatchPollInterval    = 10 * time.Second
+	defaultBatchMaxPollInterval = 5 * time.Minute
+	defaultBatchPollMultiplier  = 1.5
+	defaultBatchMaxRetries      = 2
+)
+
+var ErrBatchCustomID = errors.New("batch lines need unique, non-empty custom IDs")
+
+// BatchProgress reports the state of one of the batches of a BatchRunner.
+type BatchProgress struct {
+	// Attempt is 0 for the first submission and grows with every resubmission of failed lines.
+	Attempt int
+	// Shard is the index of the batch among the Shards batches of the attempt.
+	Shard  int
+	Shards int
+	Batch  Batch
+}
+
+// BatchRunner runs any number of batch lines through the Batch API. It shards the lines into files
+// within the API limits, creates a batch per shard, waits for the batches with an exponential backoff,
+// resubmits the lines that failed and merges the results.
+type BatchRunner struct {
+	client *Client
+
+	Endpoint         BatchEndpoint
+	CompletionWindow string
+	Metadata         map[string]any
+
+	// MaxLines and MaxBytes bound the size of each uploaded file.
+	MaxLines int
+	MaxBytes int
+
+	// PollInterval is the delay before the first poll of a batch. It grows by PollMultiplier up to MaxPollInterval.
+	// A PollInterval of zero or less and a PollMultiplier under one use the defaults.
+	PollInterval    time.Duration
+	MaxPollInterval time.Duration
+	PollMultiplier  float64
+
+	// MaxRetries is the number of times failed lines are resubmitted. Only lines that failed with a
+	// rate limit or server error, or that were not processed, are resubmitted.
+	MaxRetries int
+
+	// OnProgress is called after every poll of a batch. Calls are serialized.
+	OnProgress func(progress BatchProgress)
+}
+
+// NewBatchRunner creates a BatchRunner with the limits of the Batch API and default polling settings.
+func NewBatchRunner(client *Client, endpoint BatchEndpoint) *BatchRunner {
+	return &BatchRunner{
+		client:          client,
+		Endpoint:        endpoint,
+		MaxLines:        defaultBatchMaxLines,
+		MaxBytes:        defaultBatchMaxBytes,
+		PollInterval:    defaultBatchPollInterval,
+		MaxPollInterval: defaultBatchMaxPollInterval,
+		PollMultiplier:  defaultBatchPollMultiplier,
+		MaxRetries:      defaultBatchMaxRetries,
+	}
+}
+
+// Run submits the lines and waits for all of their results. The results are returned in the order of
+// the lines, with the Request of each result set to its line. Lines that failed and could not be
+// resubmitted are returned with their Error set, including the lines of a batch that failed.
+//
+// When the context is done, the batches in progress are cancelled and the context error is returned.
+func (r *BatchRunner) Run(ctx context.Context, lines []BatchLineItem) ([]BatchResult, error) {
+	order := make(map[string]int, len(lines))
+	for i, line := range lines {
+		customID := batchLineCustomID(line)
+		if customID == "" {
+			return nil, fmt.Errorf("%w: line %d has no custom ID", ErrBatchCustomID, i)
+		}
+		if previous, ok := order[customID]; ok {
+			return nil, fmt.Errorf("%w: lines %d and %d have custom ID %q", ErrBatchCustomID, previous, i, customID)
+		}
+		order[customID] = i
+	}
+	results := make([]BatchResult, len(lines))
+
+	pending := lines
+	for attempt := 0; len(pending) > 0; attempt++ {
+		attemptResults, err := r.runAttempt(ctx, attempt, pending)
+		if err != nil {
+			return nil, err
+		}
+
+		pending = nil
+		for _, result := range attemptResults {
+			if result.Error != nil && attempt < r.MaxRetries && isRetryableBatchResult(result) {
+				pending = append(pending, result.Request)
+				continue
+			}
+			results[order[result.CustomID]] = result
+		}
+	}
+	return results, nil
+}
+
+type batchShard struct {
+	lines []BatchLineItem
+	batch Batch
+}
+
+// runAttempt submits the lines as one batch per shard and returns a result for every line.
+func (r *BatchRunner) runAttempt(ctx context.Context, attempt int, lines []BatchLineItem) ([]BatchResult, error) {
+	shards, err := r.shard(lines)
+	if err != nil {
+		return nil, err
+	}
+
+	// Shards are submitted one at a time, to stay within the file and batch rate limits.
+	for i := range shards {
+		batch, err := r.submit(ctx, fmt.Sprintf("batch-%d-%d.jsonl", attempt, i), shards[i].lines)
+		if err != nil {
+			r.cancel(ctx, shards[:i])
+			return nil, err
+		}
+		shards[i].batch = batch
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		results  []BatchResult
+		firstErr error
+	)
+	pollCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	for i := range shards {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			shardResults, err := r.wait(pollCtx, attempt, i, len(shards), &shards[i], &mu)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				return
+			}
+			results = append(results, shardResults...)
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		r.cancel(ctx, shards)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, firstErr
+	}
+	return results, nil
+}
+
+// shard splits the lines into groups within MaxLines and MaxBytes.
+func (r *BatchRunner) shard(lines []BatchLineItem) ([]batchShard, error) {
+	var shards []batchShard
+	var current batchShard
+	size := 0
+	for i, line := range lines {
+		data, err := line.MarshalBatchLineItem()
+		if err != nil {
+			return nil, fmt.Errorf("batch line %d: %w", i, err)
+		}
+		lineSize := len(data) + 1
+		full := (r.MaxLines > 0 && len(current.lines) >= r.MaxLines) ||
+			(r.MaxBytes > 0 && size+lineSize > r.MaxBytes)
+		if full && len(current.lines) > 0 {
+			shards = append(shards, current)
+			current, size = batchShard{}, 0
+		}
+		current.lines = append(current.lines, line)
+		size += lineSize
+	}
+	if len(current.lines) > 0 {
+		shards = append(shards, current)
+	}
+	return shards, nil
+}
+
+func (r *BatchRunner) submit(ctx context.Context, fileName string, lines []BatchLineItem) (Batch, error) {
+	file, err := r.client.UploadBatchFile(ctx, UploadBatchFileRequest{
+		FileName: fileName,
+		Lines:    lines,
+	})
+	if err != nil {
+		return Batch{}, err
+	}
+
+	response, err := r.client.CreateBatch(ctx, CreateBatchRequest{
+		InputFileID:      file.ID,
+		Endpoint:         r.Endpoint,
+		CompletionWindow: r.CompletionWindow,
+		Metadata:         r.Metadata,
+	})
+	return response.Batch, err
+}
+
+// wait polls the batch of the shard until it ends and reads its results. Lines without a result
+// are returned as failed, so that they are resubmitted.
+func (r *BatchRunner) wait(
+	ctx context.Context,
+	attempt, index, shards int,
+	shard *batchShard,
+	progressMu *sync.Mutex,
+) ([]BatchResult, error) {
+	interval := r.PollInterval
+	if interval <= 0 {
+		interval = defaultBatchPollInterval
+	}
+	multiplier := r.PollMultiplier
+	if multiplier < 1 {
+		multiplier = defaultBatchPollMultiplier
+	}
+	for !isTerminalBatchStatus(shard.batch.Status) {
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		interval = time.Duration(float64(interval) * multiplier)
+		if r.MaxPollInterval > 0 && interval > r.MaxPollInterval {
+			interval = r.MaxPollInterval
+		}
+
+		response, err := r.client.RetrieveBatch(ctx, shard.batch.ID)
+		if err != nil {
+			return nil, err
+		}
+		shard.batch = response.Batch
+
+		if r.OnProgress != nil {
+			progressMu.Lock()
+			r.OnProgress(BatchProgress{Attempt: attempt, Shard: index, Shards: shards, Batch: shard.batch})
+			progressMu.Unlock()
+		}
+	}
+
+	if shard.batch.Status == BatchStatusFailed {
+		return batchFailedResults(shard), nil
+	}
+
+	var results []BatchResult
+	var unanswered []BatchLineItem
+	if shard.batch.OutputFileID != nil || shard.batch.ErrorFileID != nil {
+		reader, err := r.client.GetBatchResults(ctx, shard.batch)
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+		reader.JoinLines(shard.lines)
+
+		for {
+			result, err := reader.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			if result.Request == nil {
+				// Not a line of this shard.
+				continue
+			}
+			results = append(results, result)
+		}
+		unanswered = reader.Unanswered()
+	} else {
+		unanswered = shard.lines
+	}
+
+	// Lines of expired or cancelled batches may have no result at all.
+	for _, line := range unanswered {
+		results = append(results, BatchResult{
+			CustomID: batchLineCustomID(line),
+			Error:    &APIError{Message: "batch line was not processed"},
+			Request:  line,
+		})
+	}
+	return results, nil
+}
+
+// cancel cancels the batches that were created, ignoring errors since the context may already be done.
+func (r *BatchRunner) cancel(ctx context.Context, shards []batchShard) {
+	ctx = context.WithoutCancel(ctx)
+	for _, shard := range shards {
+		if shard.batch.ID != "" && !isTerminalBatchStatus(shard.batch.Status) {
+			_, _ = r.client.CancelBatch(ctx, shard.batch.ID)
+		}
+	}
+}
+
+// batchFailedResults returns a failed result for every line of a shard whose batch failed. Lines the
+// batch errors point at fail as invalid requests. When the errors only point at lines, the other lines
+// fail as not processed, so that they are resubmitted without the invalid ones. Otherwise the batch
+// was rejected as a whole, and the other lines fail as invalid requests too.
+func batchFailedResults(shard *batchShard) []BatchResult {
+	message := fmt.Sprintf("batch %s failed", shard.batch.ID)
+	lineErrors := make(map[int]*APIError)
+	batchLevel := false
+	if shard.batch.Errors != nil {
+		for _, batchErr := range shard.batch.Errors.Data {
+			if batchErr.Line == nil {
+				message += ": " + batchErr.Message
+				batchLevel = true
+				continue
+			}
+			// Lines are numbered from 1.
+			lineErrors[*batchErr.Line-1] = &APIError{
+				Code:           batchErr.Code,
+				Message:        batchErr.Message,
+				Param:          batchErr.Param,
+				Type:           "invalid_request_error",
+				HTTPStatusCode: http.StatusBadRequest,
+			}
+		}
+	}
+
+	rejected := batchLevel || len(lineErrors) == 0
+
+	results := make([]BatchResult, len(shard.lines))
+	for i, line := range shard.lines {
+		lineErr, ok := lineErrors[i]
+		if !ok {
+			lineErr = &APIError{Message: message}
+			if rejected {
+				lineErr.Type = "invalid_request_error"
+				lineErr.HTTPStatusCode = http.StatusBadRequest
+			}
+		}
+		results[i] = BatchResult{CustomID: batchLineCustomID(line), Error: lineErr, Request: line}
+	}
+	return results
+}
+
+// isRetryableBatchResult reports whether a failed line may succeed when resubmitted: it was not
+// processed, was rate limited or met a server error.
+func isRetryableBatchResult(result BatchResult) bool {
+	status := result.StatusCode
+	if status == 0 && result.Error != nil {
+		status = result.Error.HTTPStatusCode
+	}
+	return status == 0 || status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+func isTerminalBatchStatus(status string) bool {
+	switch status {
+	case BatchStatusCompleted, BatchStatusFailed, BatchStatusExpired, BatchStatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,275 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/neospace-ai/go-openai"
+	"github.com/neospace-ai/go-openai/internal/test"
+	"github.com/neospace-ai/go-openai/internal/test/checks"
+)
+
+// registerBatchRunnerHandlers serves uploads as file-in-1, file-in-2, ... and batches named after their
+// input file, with the given status. Each output file returns the lines in outputs, keyed by input file.
+// It returns the number of cancelled batches.
+func registerBatchRunnerHandlers(
+	t *testing.T,
+	server *test.ServerTest,
+	status string,
+	outputs map[string]string,
+) *atomic.Int32 {
+	t.Helper()
+	cancelled := &atomic.Int32{}
+	var mu sync.Mutex
+	uploads := 0
+	server.RegisterHandler("/v1/files", func(w http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		uploads++
+		fmt.Fprintf(w, `{"id":"file-in-%d"}`, uploads)
+	})
+	server.RegisterHandler("/v1/batches", func(w http.ResponseWriter, r *http.Request) {
+		var request openai.CreateBatchRequest
+		checks.NoError(t, json.NewDecoder(r.Body).Decode(&request), "Decode error")
+		fmt.Fprintf(w, `{"id":"batch-%s","status":"validating"}`, request.InputFileID)
+	})
+	server.RegisterHandler("/v1/batches/*", func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/cancel") {
+			cancelled.Add(1)
+			fmt.Fprintln(w, `{"status":"cancelling"}`)
+			return
+		}
+		inputFileID := strings.TrimPrefix(r.URL.Path, "/v1/batches/batch-")
+		fmt.Fprintf(w, `{"id":"batch-%s","status":%q,"endpoint":"/v1/chat/completions","output_file_id":"out-%s"}`,
+			inputFileID, status, inputFileID)
+	})
+	server.RegisterHandler("/v1/files/*", func(w http.ResponseWriter, r *http.Request) {
+		inputFileID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1/files/out-"), "/content")
+		fmt.Fprint(w, outputs[inputFileID])
+	})
+	return cancelled
+}
+
+func batchOutputLine(customID string, statusCode int, content string) string {
+	body := fmt.Sprintf(`{"choices":[{"message":{"role":"assistant","content":%q}}]}`, content)
+	if statusCode != http.StatusOK {
+		body = `{"error":{"message":"server error"}}`
+	}
+	return fmt.Sprintf(`{"custom_id":%q,"response":{"status_code":%d,"body":%s}}`+"\n", customID, statusCode, body)
+}
+
+func TestBatchRunner(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	registerBatchRunnerHandlers(t, server, openai.BatchStatusCompleted, map[string]string{
+		// First attempt, two shards. req-b fails and is resubmitted in a third file.
+		"file-in-1": batchOutputLine("req-a", 200, "A") + batchOutputLine("req-b", 500, ""),
+		"file-in-2": batchOutputLine("req-c", 200, "C"),
+		"file-in-3": batchOutputLine("req-b", 200, "B"),
+	})
+
+	request := openai.UploadBatchFileRequest{}
+	for _, id := range []string{"req-a", "req-b", "req-c"} {
+		request.AddChatCompletion(id, openai.ChatCompletionRequest{Model: openai.GPT4oMini})
+	}
+
+	var progress []openai.BatchProgress
+	runner := openai.NewBatchRunner(client, openai.BatchEndpointChatCompletions)
+	runner.MaxLines = 2
+	runner.PollInterval = time.Millisecond
+	runner.OnProgress = func(p openai.BatchProgress) {
+		progress = append(progress, p)
+	}
+
+	results, err := runner.Run(context.Background(), request.Lines)
+	checks.NoErrorF(t, err, "Run error")
+
+	var contents []string
+	for _, result := range results {
+		if result.Error != nil || result.ChatCompletion == nil {
+			t.Fatalf("unexpected failed result: %+v", result)
+		}
+		contents = append(contents, result.ChatCompletion.Choices[0].Message.Content)
+	}
+	if strings.Join(contents, "") != "ABC" {
+		t.Errorf("results were not merged in order: %v", contents)
+	}
+	if len(progress) != 3 || progress[len(progress)-1].Attempt != 1 {
+		t.Errorf("unexpected progress reports: %+v", progress)
+	}
+}
+
+func TestBatchRunnerRetriesExhausted(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	registerBatchRunnerHandlers(t, server, openai.BatchStatusExpired, map[string]string{})
+
+	request := openai.UploadBatchFileRequest{}
+	request.AddChatCompletion("req-a", openai.ChatCompletionRequest{Model: openai.GPT4oMini})
+
+	runner := openai.NewBatchRunner(client, openai.BatchEndpointChatCompletions)
+	runner.PollInterval = time.Millisecond
+	runner.MaxRetries = 1
+
+	results, err := runner.Run(context.Background(), request.Lines)
+	checks.NoErrorF(t, err, "Run error")
+	if len(results) != 1 || results[0].Error == nil || results[0].CustomID != "req-a" {
+		t.Errorf("expected the unprocessed line to be reported as failed: %+v", results)
+	}
+}
+
+func TestBatchRunnerCancel(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	cancelled := registerBatchRunnerHandlers(t, server, openai.BatchStatusInProgress, nil)
+
+	request := openai.UploadBatchFileRequest{}
+	request.AddChatCompletion("req-a", openai.ChatCompletionRequest{Model: openai.GPT4oMini})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runner := openai.NewBatchRunner(client, openai.BatchEndpointChatCompletions)
+	runner.PollInterval = time.Millisecond
+	runner.OnProgress = func(openai.BatchProgress) { cancel() }
+
+	_, err := runner.Run(ctx, request.Lines)
+	checks.ErrorIs(t, err, context.Canceled, "Run did not return the context error")
+	if cancelled.Load() != 1 {
+		t.Errorf("expected the batch to be cancelled once, got %d", cancelled.Load())
+	}
+}
+
+func TestBatchRunnerFailedBatch(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var mu sync.Mutex
+	uploads := 0
+	server.RegisterHandler("/v1/files", func(w http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		uploads++
+		fmt.Fprintf(w, `{"id":"file-in-%d"}`, uploads)
+	})
+	server.RegisterHandler("/v1/batches", func(w http.ResponseWriter, r *http.Request) {
+		var request openai.CreateBatchRequest
+		checks.NoError(t, json.NewDecoder(r.Body).Decode(&request), "Decode error")
+		fmt.Fprintf(w, `{"id":"batch-%s","status":"validating"}`, request.InputFileID)
+	})
+	cancelled := &atomic.Int32{}
+	server.RegisterHandler("/v1/batches/*", func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/cancel") {
+			cancelled.Add(1)
+			fmt.Fprintln(w, `{"status":"cancelling"}`)
+			return
+		}
+		inputFileID := strings.TrimPrefix(r.URL.Path, "/v1/batches/batch-")
+		if inputFileID == "file-in-2" {
+			// The second line of the file is invalid, and the batch fails as a whole.
+			fmt.Fprintf(w, `{"id":"batch-%s","status":"failed","endpoint":"/v1/chat/completions","errors":{"data":[`+
+				`{"code":"invalid_model","message":"bad model","line":2}]}}`, inputFileID)
+			return
+		}
+		fmt.Fprintf(w, `{"id":"batch-%s","status":"completed","endpoint":"/v1/chat/completions","output_file_id":"out-%s"}`,
+			inputFileID, inputFileID)
+	})
+	outputs := map[string]string{
+		"file-in-1": batchOutputLine("req-a", 200, "A") + batchOutputLine("req-b", 400, ""),
+		"file-in-3": batchOutputLine("req-c", 200, "C"),
+	}
+	server.RegisterHandler("/v1/files/*", func(w http.ResponseWriter, r *http.Request) {
+		inputFileID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1/files/out-"), "/content")
+		fmt.Fprint(w, outputs[inputFileID])
+	})
+
+	request := openai.UploadBatchFileRequest{}
+	for _, id := range []string{"req-a", "req-b", "req-c", "req-d"} {
+		request.AddChatCompletion(id, openai.ChatCompletionRequest{Model: openai.GPT4oMini})
+	}
+	runner := openai.NewBatchRunner(client, openai.BatchEndpointChatCompletions)
+	runner.MaxLines = 2
+	runner.PollInterval = time.Millisecond
+
+	results, err := runner.Run(context.Background(), request.Lines)
+	checks.NoErrorF(t, err, "Run error")
+	if len(results) != 4 || results[0].Error != nil || results[2].Error != nil {
+		t.Fatalf("expected req-a and req-c to succeed: %+v", results)
+	}
+	// The client error of req-b is not retried, and neither is the invalid line req-d.
+	if results[1].Error == nil || results[1].StatusCode != http.StatusBadRequest {
+		t.Errorf("unexpected result of req-b: %+v", results[1])
+	}
+	if results[3].Error == nil || results[3].Error.Code != "invalid_model" {
+		t.Errorf("unexpected result of req-d: %+v", results[3])
+	}
+	if uploads != 3 || cancelled.Load() != 0 {
+		t.Errorf("expected 3 uploads and no cancellation, got %d and %d", uploads, cancelled.Load())
+	}
+}
+
+func TestBatchRunnerRejectedBatch(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	// The batch fails without pointing at any line, as when its input file is malformed.
+	registerBatchRunnerHandlers(t, server, openai.BatchStatusFailed, nil)
+
+	request := openai.UploadBatchFileRequest{}
+	request.AddChatCompletion("req-a", openai.ChatCompletionRequest{Model: openai.GPT4oMini})
+
+	var attempts []int
+	runner := openai.NewBatchRunner(client, openai.BatchEndpointChatCompletions)
+	runner.PollInterval = time.Millisecond
+	runner.OnProgress = func(p openai.BatchProgress) {
+		attempts = append(attempts, p.Attempt)
+	}
+
+	results, err := runner.Run(context.Background(), request.Lines)
+	checks.NoErrorF(t, err, "Run error")
+	if len(results) != 1 || results[0].Error == nil || results[0].Error.HTTPStatusCode != http.StatusBadRequest {
+		t.Fatalf("expected the line to fail as an invalid request: %+v", results)
+	}
+	if len(attempts) != 1 || attempts[0] != 0 {
+		t.Errorf("expected the rejected batch not to be resubmitted, got attempts %v", attempts)
+	}
+}
+
+func TestBatchRunnerDefaultsPollSettings(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	registerBatchRunnerHandlers(t, server, openai.BatchStatusInProgress, nil)
+
+	request := openai.UploadBatchFileRequest{}
+	request.AddChatCompletion("req-a", openai.ChatCompletionRequest{Model: openai.GPT4oMini})
+
+	polls := 0
+	runner := openai.NewBatchRunner(client, openai.BatchEndpointChatCompletions)
+	runner.PollInterval = 0
+	runner.PollMultiplier = 0
+	runner.OnProgress = func(openai.BatchProgress) { polls++ }
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := runner.Run(ctx, request.Lines)
+	checks.ErrorIs(t, err, context.DeadlineExceeded, "Run did not return the context error")
+	if polls != 0 {
+		t.Errorf("expected no poll before the default interval, got %d", polls)
+	}
+}
+
+func TestBatchRunnerCustomIDs(t *testing.T) {
+	runner := openai.NewBatchRunner(openai.NewClient("token"), openai.BatchEndpointChatCompletions)
+	for _, ids := range [][]string{{"req-a", "req-a"}, {"req-a", ""}} {
+		request := openai.UploadBatchFileRequest{}
+		for _, id := range ids {
+			request.AddChatCompletion(id, openai.ChatCompletionRequest{Model: openai.GPT4oMini})
+		}
+		_, err := runner.Run(context.Background(), request.Lines)
+		checks.ErrorIs(t, err, openai.ErrBatchCustomID, "Run did not reject the custom IDs")
+	}
+}
@@ -2,6 +2,7 @@ package openai_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -137,7 +138,8 @@ func TestUploadBatchFileRequest_AddChatCompletion(t *testing.T) {
 			for _, arg := range tt.args {
 				r.AddChatCompletion(arg.customerID, arg.body)
 			}
-			got := r.MarshalJSONL()
+			got, err := r.MarshalJSONL()
+			checks.NoError(t, err, "MarshalJSONL error")
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("Marshal() got = %v, want %v", got, tt.want)
 			}
@@ -178,7 +180,8 @@ func TestUploadBatchFileRequest_AddCompletion(t *testing.T) {
 			for _, arg := range tt.args {
 				r.AddCompletion(arg.customerID, arg.body)
 			}
-			got := r.MarshalJSONL()
+			got, err := r.MarshalJSONL()
+			checks.NoError(t, err, "MarshalJSONL error")
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("Marshal() got = %v, want %v", got, tt.want)
 			}
@@ -219,7 +222,8 @@ func TestUploadBatchFileRequest_AddEmbedding(t *testing.T) {
 			for _, arg := range tt.args {
 				r.AddEmbedding(arg.customerID, arg.body)
 			}
-			got := r.MarshalJSONL()
+			got, err := r.MarshalJSONL()
+			checks.NoError(t, err, "MarshalJSONL error")
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("Marshal() got = %v, want %v", got, tt.want)
 			}
@@ -366,3 +370,59 @@ func handleCancelBatchEndpoint(w http.ResponseWriter, r *http.Request) {
 		}`)
 	}
 }
+
+func TestUploadBatchFileRequest_AddSupervisor(t *testing.T) {
+	r := &openai.UploadBatchFileRequest{}
+	r.AddSupervisor("req-1", openai.SupervisorRequest{
+		Model:        "supervisor",
+		MaxTokens:    5,
+		InstructTask: openai.GenericTask{Task: &openai.TaskGuard{GuardSafe: true}},
+	})
+	got, err := r.MarshalJSONL()
+	checks.NoError(t, err, "MarshalJSONL error")
+
+	var line struct {
+		CustomID string               `json:"custom_id"`
+		Body     map[string]any       `json:"body"`
+		URL      openai.BatchEndpoint `json:"url"`
+	}
+	checks.NoError(t, json.Unmarshal(got, &line), "Unmarshal error")
+	if line.CustomID != "req-1" || line.URL != openai.BatchEndpointSupervisor {
+		t.Errorf("unexpected supervisor line: %s", got)
+	}
+	if line.Body["model"] != "supervisor" || line.Body["prompt"] == nil {
+		t.Errorf("supervisor line body is not the neolang input: %s", got)
+	}
+}
+
+func TestUploadBatchFileRequest_AddEmbeddingTokens(t *testing.T) {
+	r := &openai.UploadBatchFileRequest{}
+	r.AddEmbedding("req-1", openai.EmbeddingRequestTokens{
+		Input: [][]int{{1, 2}},
+		Model: openai.SmallEmbedding3,
+	})
+	got, err := r.MarshalJSONL()
+	checks.NoError(t, err, "MarshalJSONL error")
+	want := `{"custom_id":"req-1","body":{"input":[[1,2]],"model":"text-embedding-3-small","user":""},` +
+		`"method":"POST","url":"/v1/embeddings"}`
+	if string(got) != want {
+		t.Errorf("MarshalJSONL() got = %s, want %s", got, want)
+	}
+}
+
+func TestUploadBatchFileRequest_MarshalError(t *testing.T) {
+	r := &openai.UploadBatchFileRequest{}
+	r.AddChatCompletion("req-1", openai.ChatCompletionRequest{
+		Messages: []openai.ChatCompletionMessage{{
+			Role:         openai.ChatMessageRoleUser,
+			Content:      "Hello!",
+			MultiContent: []openai.ChatMessagePart{{Type: openai.ChatMessagePartTypeText, Text: "Hello!"}},
+		}},
+	})
+	_, err := r.MarshalJSONL()
+	checks.ErrorIs(t, err, openai.ErrContentFieldsMisused, "MarshalJSONL did not return the marshal error")
+
+	client := openai.NewClient("")
+	_, err = client.UploadBatchFile(context.Background(), *r)
+	checks.ErrorIs(t, err, openai.ErrContentFieldsMisused, "UploadBatchFile did not return the marshal error")
+}
@@ -0,0 +1,148 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ChatCompletionStreamEventType identifies the kind of payload carried by a ChatCompletionStreamEvent.
+type ChatCompletionStreamEventType string
+
+const (
+	ChatCompletionStreamEventContent    ChatCompletionStreamEventType = "content"
+	ChatCompletionStreamEventReasoning  ChatCompletionStreamEventType = "reasoning"
+	ChatCompletionStreamEventToolCall   ChatCompletionStreamEventType = "tool_call"
+	ChatCompletionStreamEventTaskResult ChatCompletionStreamEventType = "task_result"
+	ChatCompletionStreamEventFinish     ChatCompletionStreamEventType = "finish"
+	ChatCompletionStreamEventUsage      ChatCompletionStreamEventType = "usage"
+	ChatCompletionStreamEventDone       ChatCompletionStreamEventType = "done"
+	ChatCompletionStreamEventError      ChatCompletionStreamEventType = "error"
+)
+
+// ChatCompletionStreamEvent is a single typed event produced from a chat completion stream.
+// Only the fields relevant to Type are set. FinishReason is set on every event of the chunk that
+// finishes a choice, and a finish event carries it when that chunk has no other event.
+type ChatCompletionStreamEvent struct {
+	Type ChatCompletionStreamEventType
+	// Index is the choice index the event belongs to.
+	Index int
+	// Delta holds the text delta for content and reasoning events.
+	Delta        string
+	ToolCall     *ToolCall
+	TaskResults  *TaskResultCollection
+	FinishReason FinishReason
+	Usage        *Usage
+	Err          error
+	// Response is the raw chunk the event was produced from. It is nil for done and error events.
+	Response *ChatCompletionStreamResponse
+}
+
+// ChatCompletionStreamEventHandler is called for every event read from the stream.
+// Returning an error stops the stream and OnEvent returns that error.
+type ChatCompletionStreamEventHandler func(event ChatCompletionStreamEvent) error
+
+// OnEvent reads the stream until it finishes and calls handler for every event.
+// A done event is emitted when the stream terminates normally and an error event
+// when reading fails. The stream is closed when OnEvent returns; cancelling ctx
+// closes it immediately and OnEvent returns ctx.Err().
+func (stream *ChatCompletionStream) OnEvent(ctx context.Context, handler ChatCompletionStreamEventHandler) error {
+	stop := context.AfterFunc(ctx, func() {
+		stream.Close()
+	})
+	defer func() {
+		stop()
+		stream.Close()
+	}()
+
+	for {
+		response, err := stream.Recv()
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if errors.Is(err, io.EOF) {
+			return handler(ChatCompletionStreamEvent{Type: ChatCompletionStreamEventDone})
+		}
+		if err != nil {
+			handlerErr := handler(ChatCompletionStreamEvent{Type: ChatCompletionStreamEventError, Err: err})
+			if handlerErr != nil {
+				return handlerErr
+			}
+			return err
+		}
+
+		for _, event := range response.events() {
+			if err = handler(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Events returns a channel of typed events read from the stream. The channel is
+// closed once the stream finishes, fails or ctx is cancelled, and the stream is
+// closed with it. Callers must either drain the channel or cancel ctx.
+func (stream *ChatCompletionStream) Events(ctx context.Context) <-chan ChatCompletionStreamEvent {
+	events := make(chan ChatCompletionStreamEvent)
+	go func() {
+		defer close(events)
+		_ = stream.OnEvent(ctx, func(event ChatCompletionStreamEvent) error {
+			select {
+			case events <- event:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+	return events
+}
+
+// events splits a stream chunk into typed events, in choice order.
+func (r *ChatCompletionStreamResponse) events() []ChatCompletionStreamEvent {
+	var events []ChatCompletionStreamEvent
+	for i := range r.Choices {
+		choice := &r.Choices[i]
+		emitted := len(events)
+		event := ChatCompletionStreamEvent{
+			Index:        choice.Index,
+			FinishReason: choice.FinishReason,
+			Response:     r,
+		}
+		if choice.Delta.Reasoning != "" {
+			event.Type = ChatCompletionStreamEventReasoning
+			event.Delta = choice.Delta.Reasoning
+			events = append(events, event)
+		}
+		if choice.Delta.Content != "" {
+			event.Type = ChatCompletionStreamEventContent
+			event.Delta = choice.Delta.Content
+			events = append(events, event)
+		}
+		event.Delta = ""
+		for j := range choice.Delta.ToolCalls {
+			event.Type = ChatCompletionStreamEventToolCall
+			event.ToolCall = &choice.Delta.ToolCalls[j]
+			events = append(events, event)
+		}
+		event.ToolCall = nil
+		if !choice.TaskResults.isEmpty() {
+			event.Type = ChatCompletionStreamEventTaskResult
+			event.TaskResults = &choice.TaskResults
+			events = append(events, event)
+		}
+		if choice.FinishReason != "" && len(events) == emitted {
+			event.Type = ChatCompletionStreamEventFinish
+			event.TaskResults = nil
+			events = append(events, event)
+		}
+	}
+	if r.Usage != nil {
+		events = append(events, ChatCompletionStreamEvent{
+			Type:     ChatCompletionStreamEventUsage,
+			Usage:    r.Usage,
+			Response: r,
+		})
+	}
+	return events
+}
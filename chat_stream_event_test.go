@@ -0,0 +1,157 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/neospace-ai/go-openai"
+	"github.com/neospace-ai/go-openai/internal/test/checks"
+)
+
+func newEventTestStream(
+	t *testing.T,
+	handler func(w http.ResponseWriter, r *http.Request),
+) *openai.ChatCompletionStream {
+	t.Helper()
+	client, server, teardown := setupOpenAITestServer()
+	t.Cleanup(teardown)
+	server.RegisterHandler("/v1/chat/completions", handler)
+
+	stream, err := client.CreateChatCompletionStream(context.Background(), openai.ChatCompletionRequest{
+		Model: openai.GPT3Dot5Turbo,
+		Messages: []openai.ChatCompletionMessage{
+			{
+				Role:    openai.ChatMessageRoleUser,
+				Content: "Hello!",
+			},
+		},
+	})
+	checks.NoErrorF(t, err, "CreateChatCompletionStream returned error")
+	return stream
+}
+
+func TestChatCompletionStreamOnEvent(t *testing.T) {
+	stream := newEventTestStream(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		dataBytes := []byte(`data: {"id":"1","choices":[{"index":0,"delta":{"reasoning":"think","content":"hi"}}]}` + "\n\n" +
+			`data: {"id":"2","choices":[{"index":0,"delta":{"tool_calls":[{"id":"call_1","type":"function",` +
+			`"function":{"name":"f"}}]},"task_results":{"raw_response":"<|guard|>"}}]}` + "\n\n" +
+			`data: {"id":"3","choices":[{"index":0,"delta":{},"finish_reason":"stop"}]}` + "\n\n" +
+			`data: {"id":"4","choices":[],"usage":{"prompt_tokens":1,"completion_tokens":2,"total_tokens":3}}` + "\n\n" +
+			"data: [DONE]\n\n")
+		_, err := w.Write(dataBytes)
+		checks.NoError(t, err, "Write error")
+	})
+
+	var received []openai.ChatCompletionStreamEvent
+	err := stream.OnEvent(context.Background(), func(event openai.ChatCompletionStreamEvent) error {
+		received = append(received, event)
+		return nil
+	})
+	checks.NoError(t, err, "OnEvent returned error")
+
+	expectedTypes := []openai.ChatCompletionStreamEventType{
+		openai.ChatCompletionStreamEventReasoning,
+		openai.ChatCompletionStreamEventContent,
+		openai.ChatCompletionStreamEventToolCall,
+		openai.ChatCompletionStreamEventTaskResult,
+		openai.ChatCompletionStreamEventFinish,
+		openai.ChatCompletionStreamEventUsage,
+		openai.ChatCompletionStreamEventDone,
+	}
+	if len(received) != len(expectedTypes) {
+		t.Fatalf("expected %d events, got %d: %+v", len(expectedTypes), len(received), received)
+	}
+	for i, expectedType := range expectedTypes {
+		if received[i].Type != expectedType {
+			t.Errorf("event %d: expected type %s, got %s", i, expectedType, received[i].Type)
+		}
+	}
+	if received[0].Delta != "think" || received[1].Delta != "hi" {
+		t.Errorf("unexpected deltas: %q, %q", received[0].Delta, received[1].Delta)
+	}
+	if received[2].ToolCall == nil || received[2].ToolCall.ID != "call_1" {
+		t.Errorf("unexpected tool call event: %+v", received[2].ToolCall)
+	}
+	if received[3].TaskResults == nil || received[3].TaskResults.RawResponse != "<|guard|>" {
+		t.Errorf("unexpected task result event: %+v", received[3].TaskResults)
+	}
+	if received[4].FinishReason != openai.FinishReasonStop || received[4].Index != 0 {
+		t.Errorf("unexpected finish event: %+v", received[4])
+	}
+	if received[5].Usage == nil || received[5].Usage.TotalTokens != 3 {
+		t.Errorf("unexpected usage event: %+v", received[5].Usage)
+	}
+}
+
+func TestChatCompletionStreamOnEventHandlerError(t *testing.T) {
+	stream := newEventTestStream(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		dataBytes := []byte(`data: {"id":"1","choices":[{"index":0,"delta":{"content":"hi"}}]}` + "\n\ndata: [DONE]\n\n")
+		_, err := w.Write(dataBytes)
+		checks.NoError(t, err, "Write error")
+	})
+
+	errStop := errors.New("stop")
+	err := stream.OnEvent(context.Background(), func(openai.ChatCompletionStreamEvent) error {
+		return errStop
+	})
+	checks.ErrorIs(t, err, errStop, "OnEvent did not return the handler error")
+}
+
+func TestChatCompletionStreamEvents(t *testing.T) {
+	stream := newEventTestStream(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		dataBytes := []byte(`data: {"id":"1","choices":[{"index":0,"delta":{"content":"a"}}]}` + "\n\n" +
+			`data: {"id":"2","choices":[{"index":0,"delta":{"content":"b"}}]}` + "\n\n" +
+			"data: [DONE]\n\n")
+		_, err := w.Write(dataBytes)
+		checks.NoError(t, err, "Write error")
+	})
+
+	var content string
+	var last openai.ChatCompletionStreamEvent
+	for event := range stream.Events(context.Background()) {
+		content += event.Delta
+		last = event
+	}
+	if content != "ab" {
+		t.Errorf("expected content %q, got %q", "ab", content)
+	}
+	if last.Type != openai.ChatCompletionStreamEventDone {
+		t.Errorf("expected the last event to be done, got %s", last.Type)
+	}
+}
+
+func TestChatCompletionStreamEventsContextCancel(t *testing.T) {
+	release := make(chan struct{})
+	stream := newEventTestStream(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, err := w.Write([]byte(`data: {"id":"1","choices":[{"index":0,"delta":{"content":"a"}}]}` + "\n\n"))
+		checks.NoError(t, err, "Write error")
+		w.(http.Flusher).Flush()
+		<-release
+	})
+	// Unblock the handler before the test server shuts down.
+	t.Cleanup(func() { close(release) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := stream.Events(ctx)
+	event := <-events
+	if event.Delta != "a" {
+		t.Fatalf("expected first delta %q, got %q", "a", event.Delta)
+	}
+	cancel()
+
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Fatal("expected the events channel to be closed after cancellation")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("events channel was not closed after cancellation")
+	}
+}
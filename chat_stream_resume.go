@@ -0,0 +1,289 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// StreamResumeStrategy defines how a truncated stream is resumed.
+type StreamResumeStrategy string
+
+const (
+	// StreamResumeNone only reports truncation through a StreamTruncatedError.
+	StreamResumeNone StreamResumeStrategy = "none"
+	// StreamResumeLastEventID reconnects with the Last-Event-ID header so the server
+	// replays the events that follow the last one received.
+	StreamResumeLastEventID StreamResumeStrategy = "last_event_id"
+	// StreamResumePrefix re-issues the request with the partial assistant message
+	// appended, for backends that continue a trailing assistant message. Only the content
+	// and reasoning of a single choice are resumed: requests with N > 1 are rejected, and
+	// a stream truncated in the middle of tool calls is not resumed.
+	StreamResumePrefix StreamResumeStrategy = "prefix"
+)
+
+const defaultStreamResumeAttempts = 3
+
+// StreamResumeOptions configures CreateResumableChatCompletionStream.
+type StreamResumeOptions struct {
+	Strategy StreamResumeStrategy
+	// MaxAttempts is the number of reconnections allowed for the whole stream. Defaults to 3.
+	MaxAttempts int
+	// Backoff is the delay before each reconnection. When zero, the retry interval
+	// sent by the server is used, if any.
+	Backoff time.Duration
+}
+
+// StreamTruncatedError is returned when a stream ends before its [DONE] message
+// and could not be resumed.
+type StreamTruncatedError struct {
+	// Partial is the assistant message of the first choice accumulated so far.
+	Partial ChatCompletionMessage
+	// LastEventID is the ID of the last event received, if the server sends IDs.
+	LastEventID string
+	Err         error
+}
+
+func (e *StreamTruncatedError) Error() string {
+	return fmt.Sprintf("stream truncated after %d characters: %v", len(e.Partial.Content), e.Err)
+}
+
+func (e *StreamTruncatedError) Unwrap() error {
+	return e.Err
+}
+
+// ResumableChatCompletionStream is a chat completion stream that detects truncation
+// and optionally reconnects to continue the response.
+type ResumableChatCompletionStream struct {
+	stream *ChatCompletionStream
+
+	ctx         context.Context
+	client      *Client
+	request     ChatCompletionRequest
+	options     StreamResumeOptions
+	accumulator chatStreamAccumulator
+	attempts    int
+}
+
+// CreateResumableChatCompletionStream — API call to create a chat completion stream
+// that survives network drops. When the stream ends without a [DONE] message, Recv
+// reconnects according to options.Strategy and keeps returning the remaining chunks.
+// Once reconnection is not possible, Recv returns a *StreamTruncatedError that
+// carries the partial response.
+func (c *Client) CreateResumableChatCompletionStream(
+	ctx context.Context,
+	request ChatCompletionRequest,
+	options StreamResumeOptions,
+) (stream *ResumableChatCompletionStream, err error) {
+	switch options.Strategy {
+	case "":
+		options.Strategy = StreamResumeNone
+	case StreamResumeNone, StreamResumeLastEventID:
+	case StreamResumePrefix:
+		if request.N > 1 {
+			err = fmt.Errorf("%w: %s with %d choices", ErrStreamResumeUnsupported, options.Strategy, request.N)
+			return
+		}
+	default:
+		err = fmt.Errorf("%w: %q", ErrStreamResumeUnsupported, options.Strategy)
+		return
+	}
+	if options.MaxAttempts == 0 {
+		options.MaxAttempts = defaultStreamResumeAttempts
+	}
+
+	inner, err := c.CreateChatCompletionStream(ctx, request)
+	if err != nil {
+		return
+	}
+	inner.detectTruncation = true
+
+	request.Stream = true
+	stream = &ResumableChatCompletionStream{
+		stream:  inner,
+		ctx:     ctx,
+		client:  c,
+		request: request,
+		options: options,
+	}
+	return
+}
+
+// Recv returns the next chunk, transparently reconnecting when the stream drops.
+func (s *ResumableChatCompletionStream) Recv() (response ChatCompletionStreamResponse, err error) {
+	for {
+		response, err = s.stream.Recv()
+		if err == nil {
+			s.accumulator.add(response)
+			return
+		}
+		if !errors.Is(err, ErrStreamTruncated) || s.ctx.Err() != nil {
+			return
+		}
+
+		truncated := &StreamTruncatedError{
+			Partial:     s.accumulator.message(0),
+			LastEventID: s.stream.LastEventID(),
+			Err:         err,
+		}
+		if s.options.Strategy == StreamResumeNone || s.attempts >= s.options.MaxAttempts {
+			return response, truncated
+		}
+		s.attempts++
+
+		if resumeErr := s.resume(); resumeErr != nil {
+			truncated.Err = errors.Join(err, resumeErr)
+			return response, truncated
+		}
+	}
+}
+
+// Partial returns the assistant message of the given choice accumulated so far.
+func (s *ResumableChatCompletionStream) Partial(index int) ChatCompletionMessage {
+	return s.accumulator.message(index)
+}
+
+// LastEventID returns the ID of the last event received.
+func (s *ResumableChatCompletionStream) LastEventID() string {
+	return s.stream.LastEventID()
+}
+
+func (s *ResumableChatCompletionStream) Close() error {
+	return s.stream.Close()
+}
+
+func (s *ResumableChatCompletionStream) resume() error {
+	lastEventID := s.stream.LastEventID()
+	backoff := s.options.Backoff
+	if backoff == 0 {
+		backoff = s.stream.RetryInterval()
+	}
+	s.stream.Close()
+
+	if backoff > 0 {
+		timer := time.NewTimer(backoff)
+		defer timer.Stop()
+		select {
+		case <-s.ctx.Done():
+			return s.ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	request := s.request
+	setters := []requestOption{}
+	switch s.options.Strategy {
+	case StreamResumeLastEventID:
+		if lastEventID == "" {
+			return ErrStreamNotResumable
+		}
+		setters = append(setters, withLastEventID(lastEventID))
+	case StreamResumePrefix:
+		partial := s.accumulator.message(0)
+		if len(partial.ToolCalls) > 0 {
+			return fmt.Errorf("%w: %s with partial tool calls", ErrStreamResumeUnsupported, s.options.Strategy)
+		}
+		request.Messages = append(request.Messages[:len(request.Messages):len(request.Messages)], ChatCompletionMessage{
+			Role:      ChatMessageRoleAssistant,
+			Content:   partial.Content,
+			Reasoning: partial.Reasoning,
+		})
+	case StreamResumeNone:
+		return ErrStreamNotResumable
+	default:
+		return fmt.Errorf("%w: %q", ErrStreamResumeUnsupported, s.options.Strategy)
+	}
+	setters = append(setters, withBody(request))
+
+	req, err := s.client.newRequest(
+		s.ctx,
+		http.MethodPost,
+		s.client.fullURL(chatCompletionsSuffix, request.Model),
+		setters...,
+	)
+	if err != nil {
+		return err
+	}
+
+	resp, err := sendRequestStream[ChatCompletionStreamResponse](s.client, req)
+	if err != nil {
+		return err
+	}
+	resp.detectTruncation = true
+	resp.lastEventID = lastEventID
+	s.stream = &ChatCompletionStream{
+		streamReader: resp,
+	}
+	return nil
+}
+
+// chatStreamAccumulator rebuilds assistant messages from stream chunks.
+type chatStreamAccumulator struct {
+	choices map[int]*chatStreamChoiceAccumulator
+}
+
+type chatStreamChoiceAccumulator struct {
+	role      string
+	content   strings.Builder
+	reasoning strings.Builder
+	toolCalls []ToolCall
+}
+
+func (a *chatStreamAccumulator) add(response ChatCompletionStreamResponse) {
+	if a.choices == nil {
+		a.choices = make(map[int]*chatStreamChoiceAccumulator)
+	}
+	for _, choice := range response.Choices {
+		acc, ok := a.choices[choice.Index]
+		if !ok {
+			acc = &chatStreamChoiceAccumulator{role: ChatMessageRoleAssistant}
+			a.choices[choice.Index] = acc
+		}
+		if choice.Delta.Role != "" {
+			acc.role = choice.Delta.Role
+		}
+		acc.content.WriteString(choice.Delta.Content)
+		acc.reasoning.WriteString(choice.Delta.Reasoning)
+		for _, call := range choice.Delta.ToolCalls {
+			acc.addToolCall(call)
+		}
+	}
+}
+
+func (a *chatStreamChoiceAccumulator) addToolCall(call ToolCall) {
+	position := len(a.toolCalls)
+	if call.Index != nil {
+		position = *call.Index
+	}
+	for len(a.toolCalls) <= position {
+		a.toolCalls = append(a.toolCalls, ToolCall{})
+	}
+
+	existing := &a.toolCalls[position]
+	if call.ID != "" {
+		existing.ID = call.ID
+	}
+	if call.Type != "" {
+		existing.Type = call.Type
+	}
+	if call.Function.Name != "" {
+		existing.Function.Name = call.Function.Name
+	}
+	existing.Function.Arguments += call.Function.Arguments
+}
+
+func (a *chatStreamAccumulator) message(index int) ChatCompletionMessage {
+	acc, ok := a.choices[index]
+	if !ok {
+		return ChatCompletionMessage{Role: ChatMessageRoleAssistant}
+	}
+	return ChatCompletionMessage{
+		Role:      acc.role,
+		Content:   acc.content.String(),
+		Reasoning: acc.reasoning.String(),
+		ToolCalls: acc.toolCalls,
+	}
+}
@@ -0,0 +1,188 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/neospace-ai/go-openai"
+	"github.com/neospace-ai/go-openai/internal/test/checks"
+)
+
+func newResumableTestStream(
+	t *testing.T,
+	options openai.StreamResumeOptions,
+	handler func(w http.ResponseWriter, r *http.Request),
+) *openai.ResumableChatCompletionStream {
+	t.Helper()
+	client, server, teardown := setupOpenAITestServer()
+	t.Cleanup(teardown)
+	server.RegisterHandler("/v1/chat/completions", handler)
+
+	stream, err := client.CreateResumableChatCompletionStream(context.Background(), openai.ChatCompletionRequest{
+		Model: openai.GPT3Dot5Turbo,
+		Messages: []openai.ChatCompletionMessage{
+			{
+				Role:    openai.ChatMessageRoleUser,
+				Content: "Hello!",
+			},
+		},
+	}, options)
+	checks.NoErrorF(t, err, "CreateResumableChatCompletionStream returned error")
+	t.Cleanup(func() { stream.Close() })
+	return stream
+}
+
+func readResumableStream(stream *openai.ResumableChatCompletionStream) (content string, err error) {
+	for {
+		var response openai.ChatCompletionStreamResponse
+		response, err = stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return content, nil
+		}
+		if err != nil {
+			return content, err
+		}
+		for _, choice := range response.Choices {
+			content += choice.Delta.Content
+		}
+	}
+}
+
+func TestResumableChatCompletionStreamTruncated(t *testing.T) {
+	stream := newResumableTestStream(t, openai.StreamResumeOptions{}, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		dataBytes := []byte(`data: {"id":"1","choices":[{"index":0,"delta":{"reasoning":"hmm","content":"Hel"}}]}` + "\n\n")
+		_, err := w.Write(dataBytes)
+		checks.NoError(t, err, "Write error")
+	})
+
+	_, err := readResumableStream(stream)
+	checks.ErrorIs(t, err, openai.ErrStreamTruncated, "Recv did not return ErrStreamTruncated")
+
+	var truncated *openai.StreamTruncatedError
+	if !errors.As(err, &truncated) {
+		t.Fatalf("Recv did not return StreamTruncatedError: %v", err)
+	}
+	if truncated.Partial.Content != "Hel" || truncated.Partial.Reasoning != "hmm" {
+		t.Errorf("unexpected partial message: %+v", truncated.Partial)
+	}
+}
+
+func TestResumableChatCompletionStreamLastEventID(t *testing.T) {
+	calls := 0
+	options := openai.StreamResumeOptions{Strategy: openai.StreamResumeLastEventID}
+	stream := newResumableTestStream(t, options, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "text/event-stream")
+		var dataBytes []byte
+		if calls == 1 {
+			dataBytes = []byte("id: 1\n" + `data: {"id":"1","choices":[{"index":0,"delta":{"content":"Hel"}}]}` + "\n\n")
+		} else {
+			if r.Header.Get("Last-Event-ID") != "1" {
+				t.Errorf("expected Last-Event-ID 1, got %q", r.Header.Get("Last-Event-ID"))
+			}
+			dataBytes = []byte("id: 2\n" + `data: {"id":"1","choices":[{"index":0,"delta":{"content":"lo"}}]}` + "\n\n" +
+				"data: [DONE]\n\n")
+		}
+		_, err := w.Write(dataBytes)
+		checks.NoError(t, err, "Write error")
+	})
+
+	content, err := readResumableStream(stream)
+	checks.NoError(t, err, "stream was not resumed")
+	if content != "Hello" {
+		t.Errorf("expected content %q, got %q", "Hello", content)
+	}
+	if stream.LastEventID() != "2" {
+		t.Errorf("expected last event id 2, got %q", stream.LastEventID())
+	}
+}
+
+func TestResumableChatCompletionStreamPrefix(t *testing.T) {
+	calls := 0
+	options := openai.StreamResumeOptions{Strategy: openai.StreamResumePrefix}
+	stream := newResumableTestStream(t, options, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "text/event-stream")
+		var dataBytes []byte
+		if calls == 1 {
+			dataBytes = []byte(`data: {"id":"1","choices":[{"index":0,"delta":{"content":"Hel"}}]}` + "\n\n")
+		} else {
+			var request openai.ChatCompletionRequest
+			checks.NoError(t, json.NewDecoder(r.Body).Decode(&request), "Decode error")
+			last := request.Messages[len(request.Messages)-1]
+			if len(request.Messages) != 2 || last.Role != openai.ChatMessageRoleAssistant || last.Content != "Hel" {
+				t.Errorf("request was not re-issued with the partial message: %+v", request.Messages)
+			}
+			dataBytes = []byte(`data: {"id":"1","choices":[{"index":0,"delta":{"content":"lo"}}]}` + "\n\n" +
+				"data: [DONE]\n\n")
+		}
+		_, err := w.Write(dataBytes)
+		checks.NoError(t, err, "Write error")
+	})
+
+	content, err := readResumableStream(stream)
+	checks.NoError(t, err, "stream was not resumed")
+	if content != "Hello" {
+		t.Errorf("expected content %q, got %q", "Hello", content)
+	}
+	if stream.Partial(0).Content != "Hello" {
+		t.Errorf("expected accumulated content %q, got %q", "Hello", stream.Partial(0).Content)
+	}
+}
+
+func TestResumableChatCompletionStreamNotResumable(t *testing.T) {
+	options := openai.StreamResumeOptions{Strategy: openai.StreamResumeLastEventID}
+	stream := newResumableTestStream(t, options, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		dataBytes := []byte(`data: {"id":"1","choices":[{"index":0,"delta":{"content":"Hel"}}]}` + "\n\n")
+		_, err := w.Write(dataBytes)
+		checks.NoError(t, err, "Write error")
+	})
+
+	_, err := readResumableStream(stream)
+	checks.ErrorIs(t, err, openai.ErrStreamNotResumable, "Recv did not return ErrStreamNotResumable")
+	checks.ErrorIs(t, err, openai.ErrStreamTruncated, "Recv did not return ErrStreamTruncated")
+}
+
+func TestResumableChatCompletionStreamUnsupported(t *testing.T) {
+	client, _, teardown := setupOpenAITestServer()
+	defer teardown()
+	request := openai.ChatCompletionRequest{
+		Model:    openai.GPT3Dot5Turbo,
+		Messages: []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "Hello!"}},
+	}
+
+	_, err := client.CreateResumableChatCompletionStream(context.Background(), request,
+		openai.StreamResumeOptions{Strategy: "rewind"})
+	checks.ErrorIs(t, err, openai.ErrStreamResumeUnsupported, "unknown strategy was accepted")
+
+	request.N = 2
+	_, err = client.CreateResumableChatCompletionStream(context.Background(), request,
+		openai.StreamResumeOptions{Strategy: openai.StreamResumePrefix})
+	checks.ErrorIs(t, err, openai.ErrStreamResumeUnsupported, "prefix strategy was accepted with several choices")
+}
+
+func TestResumableChatCompletionStreamPrefixToolCalls(t *testing.T) {
+	calls := 0
+	options := openai.StreamResumeOptions{Strategy: openai.StreamResumePrefix}
+	stream := newResumableTestStream(t, options, func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "text/event-stream")
+		dataBytes := []byte(`data: {"id":"1","choices":[{"index":0,"delta":{"tool_calls":[` +
+			`{"index":0,"id":"call_1","type":"function","function":{"name":"f","arguments":"{\"a\""}}]}}]}` + "\n\n")
+		_, err := w.Write(dataBytes)
+		checks.NoError(t, err, "Write error")
+	})
+
+	_, err := readResumableStream(stream)
+	checks.ErrorIs(t, err, openai.ErrStreamResumeUnsupported, "stream with tool calls was resumed")
+	checks.ErrorIs(t, err, openai.ErrStreamTruncated, "Recv did not return ErrStreamTruncated")
+	if calls != 1 {
+		t.Errorf("expected a single request, got %d", calls)
+	}
+}
@@ -0,0 +1,244 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"sync"
+	"time"
+
+	utils "github.com/neospace-ai/go-openai/internal"
+)
+
+const (
+	defaultUploadPartSize      = 64 << 20
+	defaultUploadConcurrency   = 4
+	defaultUploadMaxRetries    = 3
+	defaultUploadRetryInterval = time.Second
+)
+
+var ErrUploadSizeUnknown = errors.New("upload size is unknown")
+
+// ChunkedUploader uploads files of any size through the Uploads API. It splits a reader into parts,
+// adds them to an upload concurrently, retrying the parts that fail, and completes the upload.
+type ChunkedUploader struct {
+	client *Client
+
+	// PartSize is the size of every part but the last one. The API accepts parts of up to 64 MB.
+	PartSize int
+	// Concurrency is the maximum number of parts uploaded at the same time. Every part in flight
+	// is held in memory.
+	Concurrency int
+
+	// MaxRetries is the number of times a part is retried after a network error, a rate limit or
+	// a server error. RetryInterval is the delay before the first retry, doubled after every retry.
+	MaxRetries    int
+	RetryInterval time.Duration
+
+	// OnProgress is called after every uploaded part with the number of bytes uploaded so far.
+	// Calls are serialized.
+	OnProgress func(uploaded, total int64)
+}
+
+// NewChunkedUploader creates a ChunkedUploader with the largest part size and default retries.
+func NewChunkedUploader(client *Client) *ChunkedUploader {
+	return &ChunkedUploader{
+		client:        client,
+		PartSize:      defaultUploadPartSize,
+		Concurrency:   defaultUploadConcurrency,
+		MaxRetries:    defaultUploadMaxRetries,
+		RetryInterval: defaultUploadRetryInterval,
+	}
+}
+
+// Upload uploads the contents of r and returns the resulting file.
+//
+// The API needs the size of the file upfront. When request.Bytes is zero, it is taken from r if r
+// knows it, like *bytes.Reader, *strings.Reader and *os.File, and ErrUploadSizeUnknown is returned
+// otherwise. When request.MimeType is empty, it is derived from the file name.
+//
+// If a part cannot be uploaded, or the context is done, the upload is cancelled.
+func (u *ChunkedUploader) Upload(ctx context.Context, request CreateUploadRequest, r io.Reader) (File, error) {
+	if request.Bytes == 0 {
+		size, ok := utils.ReaderSize(r)
+		if !ok {
+			return File{}, ErrUploadSizeUnknown
+		}
+		request.Bytes = size
+	}
+	if request.MimeType == "" {
+		request.MimeType = uploadMimeType(request.Filename)
+	}
+
+	upload, err := u.client.CreateUpload(ctx, request)
+	if err != nil {
+		return File{}, err
+	}
+
+	partIDs, err := u.uploadParts(ctx, upload.ID, request.Bytes, r)
+	if err != nil {
+		// Best effort, the caller's context may already be done.
+		_, _ = u.client.CancelUpload(context.WithoutCancel(ctx), upload.ID)
+		return File{}, err
+	}
+
+	upload, err = u.client.CompleteUpload(ctx, upload.ID, CompleteUploadRequest{PartIDs: partIDs})
+	if err != nil {
+		return File{}, err
+	}
+	if upload.File == nil {
+		return File{}, fmt.Errorf("upload %s completed without a file", upload.ID)
+	}
+	return *upload.File, nil
+}
+
+// uploadParts reads the parts one at a time and uploads them concurrently. It returns the IDs of
+// the parts in the order of the file.
+func (u *ChunkedUploader) uploadParts(ctx context.Context, uploadID string, size int64, r io.Reader) ([]string, error) {
+	partSize := u.PartSize
+	if partSize <= 0 {
+		partSize = defaultUploadPartSize
+	}
+	concurrency := u.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		uploaded int64
+		firstErr error
+	)
+	partCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr == nil {
+			firstErr = err
+			cancel()
+		}
+	}
+
+	partIDs := make([]string, (size+int64(partSize)-1)/int64(partSize))
+	sem := make(chan struct{}, concurrency)
+	var read int64
+	for index := 0; ; index++ {
+		select {
+		case <-partCtx.Done():
+		case sem <- struct{}{}:
+		}
+		if partCtx.Err() != nil {
+			break
+		}
+
+		part := make([]byte, partSize)
+		n, readErr := io.ReadFull(r, part)
+		read += int64(n)
+		if read > size {
+			<-sem
+			fail(fmt.Errorf("upload %s: reader is larger than %d bytes", uploadID, size))
+			break
+		}
+		if n == 0 {
+			<-sem
+		} else {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+				id, err := u.uploadPart(partCtx, uploadID, part[:n])
+				if err != nil {
+					fail(fmt.Errorf("upload %s part %d: %w", uploadID, index, err))
+					return
+				}
+
+				mu.Lock()
+				defer mu.Unlock()
+				partIDs[index] = id
+				uploaded += int64(n)
+				if u.OnProgress != nil {
+					u.OnProgress(uploaded, size)
+				}
+			}()
+		}
+
+		if errors.Is(readErr, io.EOF) || errors.Is(readErr, io.ErrUnexpectedEOF) {
+			break
+		}
+		if readErr != nil {
+			fail(readErr)
+			break
+		}
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if read != size {
+		return nil, fmt.Errorf("upload %s: reader has %d bytes, expected %d", uploadID, read, size)
+	}
+	return partIDs, nil
+}
+
+func (u *ChunkedUploader) uploadPart(ctx context.Context, uploadID string, part []byte) (string, error) {
+	interval := u.RetryInterval
+	for attempt := 0; ; attempt++ {
+		response, err := u.client.AddUploadPart(ctx, uploadID, bytes.NewReader(part))
+		if err == nil {
+			return response.ID, nil
+		}
+		if attempt >= u.MaxRetries || !isRetryableUploadError(err) {
+			return "", err
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return "", ctx.Err()
+		case <-timer.C:
+		}
+		interval *= 2
+	}
+}
+
+// isRetryableUploadError reports whether a failed part may succeed when sent again.
+func isRetryableUploadError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	statusCode := 0
+	var apiErr *APIError
+	var reqErr *RequestError
+	switch {
+	case errors.As(err, &apiErr):
+		statusCode = apiErr.HTTPStatusCode
+	case errors.As(err, &reqErr):
+		statusCode = reqErr.HTTPStatusCode
+	default:
+		return true
+	}
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+func uploadMimeType(filename string) string {
+	ext := path.Ext(filename)
+	if ext == ".jsonl" {
+		return "text/jsonl"
+	}
+	if mimeType, _, err := mime.ParseMediaType(mime.TypeByExtension(ext)); err == nil {
+		return mimeType
+	}
+	return "application/octet-stream"
+}
@@ -90,6 +90,12 @@ func withContentType(contentType string) requestOption {
 	}
 }
 
+func withLastEventID(id string) requestOption {
+	return func(args *requestOptions) {
+		args.header.Set("Last-Event-ID", id)
+	}
+}
+
 func withBetaAssistantVersion(version string) requestOption {
 	return func(args *requestOptions) {
 		args.header.Set("OpenAI-Beta", fmt.Sprintf("assistants=%s", version))
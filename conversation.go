@@ -0,0 +1,221 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+var (
+	ErrConversationNotFound = errors.New("conversation not found")
+	ErrConversationNoID     = errors.New("conversation has no ID")
+)
+
+// ConversationTurn is a message of a conversation along with the task results returned with it.
+// The reasoning of assistant turns is kept in Message.Reasoning.
+type ConversationTurn struct {
+	Message     ChatCompletionMessage `json:"message" bson:"message"`
+	TaskResults *TaskResultCollection `json:"task_results,omitempty" bson:"task_results,omitempty"`
+	CreatedAt   int64                 `json:"created_at" bson:"created_at"`
+}
+
+// Conversation is a local chat session. It collects the turns of a chat so that they can be
+// sent back as the history of the next ChatCompletionRequest or SupervisorRequest, and
+// persisted through a ConversationStore. A Conversation is not safe for concurrent use.
+type Conversation struct {
+	ID       string             `json:"id" bson:"id"`
+	Turns    []ConversationTurn `json:"turns" bson:"turns"`
+	Metadata map[string]string  `json:"metadata,omitempty" bson:"metadata,omitempty"`
+}
+
+// NewConversation creates an empty conversation.
+func NewConversation(id string) *Conversation {
+	return &Conversation{ID: id}
+}
+
+// AddTurn appends a turn, setting its creation time if unset.
+func (c *Conversation) AddTurn(turn ConversationTurn) {
+	if turn.CreatedAt == 0 {
+		turn.CreatedAt = time.Now().Unix()
+	}
+	c.Turns = append(c.Turns, turn)
+}
+
+// AddSystem appends a system message.
+func (c *Conversation) AddSystem(content string) {
+	c.AddTurn(ConversationTurn{Message: ChatCompletionMessage{Role: ChatMessageRoleSystem, Content: content}})
+}
+
+// AddUser appends a user message.
+func (c *Conversation) AddUser(content string) {
+	c.AddTurn(ConversationTurn{Message: ChatCompletionMessage{Role: ChatMessageRoleUser, Content: content}})
+}
+
+// AddAssistant appends an assistant message with the task results returned with it.
+func (c *Conversation) AddAssistant(message ChatCompletionMessage, taskResults TaskResultCollection) {
+	turn := ConversationTurn{Message: message}
+	if !taskResults.isEmpty() {
+		turn.TaskResults = &taskResults
+	}
+	c.AddTurn(turn)
+}
+
+// AddChoice appends the message and task results of a chat completion choice.
+func (c *Conversation) AddChoice(choice ChatCompletionChoice) {
+	c.AddAssistant(choice.Message, choice.TaskResults)
+}
+
+// Messages returns the messages of the conversation, without the reasoning of assistant turns.
+func (c *Conversation) Messages() []ChatCompletionMessage {
+	messages := make([]ChatCompletionMessage, len(c.Turns))
+	for i, turn := range c.Turns {
+		messages[i] = turn.Message
+		messages[i].Reasoning = ""
+	}
+	return messages
+}
+
+// ToChatCompletionRequest returns the request with its messages set to the conversation.
+func (c *Conversation) ToChatCompletionRequest(request ChatCompletionRequest) ChatCompletionRequest {
+	request.Messages = c.Messages()
+	return request
+}
+
+// ToSupervisorRequest returns the request with its history set to the conversation.
+func (c *Conversation) ToSupervisorRequest(request SupervisorRequest) SupervisorRequest {
+	request.History = c.Messages()
+	return request
+}
+
+// ConversationStore persists conversations by ID.
+type ConversationStore interface {
+	// Load returns ErrConversationNotFound if no conversation is stored under the ID.
+	Load(ctx context.Context, id string) (*Conversation, error)
+	Save(ctx context.Context, conversation *Conversation) error
+	Delete(ctx context.Context, id string) error
+}
+
+// MemoryConversationStore is a ConversationStore that keeps conversations in memory.
+// Conversations are stored serialized, so later changes to a saved conversation are not visible
+// until it is saved again.
+type MemoryConversationStore struct {
+	mu            sync.RWMutex
+	conversations map[string][]byte
+}
+
+// NewMemoryConversationStore creates an empty MemoryConversationStore.
+func NewMemoryConversationStore() *MemoryConversationStore {
+	return &MemoryConversationStore{conversations: make(map[string][]byte)}
+}
+
+func (s *MemoryConversationStore) Load(_ context.Context, id string) (*Conversation, error) {
+	s.mu.RLock()
+	data, ok := s.conversations[id]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrConversationNotFound, id)
+	}
+
+	conversation := &Conversation{}
+	if err := json.Unmarshal(data, conversation); err != nil {
+		return nil, err
+	}
+	return conversation, nil
+}
+
+func (s *MemoryConversationStore) Save(_ context.Context, conversation *Conversation) error {
+	if conversation.ID == "" {
+		return ErrConversationNoID
+	}
+	data, err := json.Marshal(conversation)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.conversations[conversation.ID] = data
+	return nil
+}
+
+func (s *MemoryConversationStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.conversations, id)
+	return nil
+}
+
+// FileConversationStore is a ConversationStore that keeps each conversation in a JSON file
+// of a directory.
+type FileConversationStore struct {
+	dir string
+}
+
+// NewFileConversationStore creates a FileConversationStore, creating the directory if needed.
+func NewFileConversationStore(dir string) (*FileConversationStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileConversationStore{dir: dir}, nil
+}
+
+func (s *FileConversationStore) path(id string) string {
+	return filepath.Join(s.dir, url.PathEscape(id)+".json")
+}
+
+func (s *FileConversationStore) Load(_ context.Context, id string) (*Conversation, error) {
+	data, err := os.ReadFile(s.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrConversationNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	conversation := &Conversation{}
+	if err = json.Unmarshal(data, conversation); err != nil {
+		return nil, err
+	}
+	return conversation, nil
+}
+
+// Save writes the conversation to a temporary file and renames it, so that a failed save
+// never leaves a truncated conversation behind.
+func (s *FileConversationStore) Save(_ context.Context, conversation *Conversation) error {
+	if conversation.ID == "" {
+		return ErrConversationNoID
+	}
+	data, err := json.Marshal(conversation)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(s.dir, ".conversation-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err = file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), s.path(conversation.ID))
+}
+
+func (s *FileConversationStore) Delete(_ context.Context, id string) error {
+	err := os.Remove(s.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,79 @@
+package openai_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neospace-ai/go-openai"
+	"github.com/neospace-ai/go-openai/internal/test/checks"
+)
+
+func newTestConversation() *openai.Conversation {
+	conversation := openai.NewConversation("conv/1")
+	conversation.AddSystem("You are helpful.")
+	conversation.AddUser("Hello!")
+	conversation.AddChoice(openai.ChatCompletionChoice{
+		Message: openai.ChatCompletionMessage{
+			Role:      openai.ChatMessageRoleAssistant,
+			Content:   "Hi!",
+			Reasoning: "greet back",
+		},
+		TaskResults: openai.TaskResultCollection{TaskGuard: &openai.TaskGuard{GuardSafe: true}},
+	})
+	return conversation
+}
+
+func TestConversationExport(t *testing.T) {
+	conversation := newTestConversation()
+
+	request := conversation.ToChatCompletionRequest(openai.ChatCompletionRequest{Model: openai.GPT3Dot5Turbo})
+	if request.Model != openai.GPT3Dot5Turbo || len(request.Messages) != 3 {
+		t.Fatalf("unexpected request: %+v", request)
+	}
+	if request.Messages[2].Content != "Hi!" || request.Messages[2].Reasoning != "" {
+		t.Errorf("unexpected assistant message: %+v", request.Messages[2])
+	}
+	if conversation.Turns[2].Message.Reasoning != "greet back" || conversation.Turns[2].TaskResults == nil {
+		t.Errorf("reasoning and task results were not kept on the turn: %+v", conversation.Turns[2])
+	}
+	if conversation.Turns[1].TaskResults != nil {
+		t.Errorf("unexpected task results on user turn: %+v", conversation.Turns[1].TaskResults)
+	}
+
+	supervisorRequest := conversation.ToSupervisorRequest(openai.SupervisorRequest{Model: "supervisor"})
+	if len(supervisorRequest.History) != 3 || supervisorRequest.History[1].Content != "Hello!" {
+		t.Errorf("unexpected supervisor history: %+v", supervisorRequest.History)
+	}
+}
+
+func TestConversationStores(t *testing.T) {
+	fileStore, err := openai.NewFileConversationStore(t.TempDir())
+	checks.NoErrorF(t, err, "NewFileConversationStore error")
+
+	stores := map[string]openai.ConversationStore{
+		"memory": openai.NewMemoryConversationStore(),
+		"file":   fileStore,
+	}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			_, err := store.Load(ctx, "conv/1")
+			checks.ErrorIs(t, err, openai.ErrConversationNotFound, "Load did not return ErrConversationNotFound")
+
+			checks.NoError(t, store.Save(ctx, newTestConversation()), "Save error")
+			loaded, err := store.Load(ctx, "conv/1")
+			checks.NoErrorF(t, err, "Load error")
+			if len(loaded.Turns) != 3 || loaded.Turns[2].Message.Reasoning != "greet back" ||
+				loaded.Turns[2].TaskResults == nil || !loaded.Turns[2].TaskResults.TaskGuard.GuardSafe {
+				t.Errorf("unexpected loaded conversation: %+v", loaded)
+			}
+
+			checks.NoError(t, store.Delete(ctx, "conv/1"), "Delete error")
+			_, err = store.Load(ctx, "conv/1")
+			checks.ErrorIs(t, err, openai.ErrConversationNotFound, "conversation was not deleted")
+
+			err = store.Save(ctx, openai.NewConversation(""))
+			checks.ErrorIs(t, err, openai.ErrConversationNoID, "Save did not reject a conversation without ID")
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Package dataset builds, validates and uploads chat-format training files for fine-tuning.
+package dataset
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/neospace-ai/go-openai"
+)
+
+// DefaultMaxExampleTokens is the largest example accepted for fine-tuning by the current chat models.
+// Longer examples are truncated to it during training.
+const DefaultMaxExampleTokens = 65536
+
+// Example is a training conversation. The model is trained on its assistant messages.
+type Example struct {
+	Messages []openai.ChatCompletionMessage `json:"messages"`
+	// Tools are the tools available to the model in the conversation.
+	Tools             []openai.Tool `json:"tools,omitempty"`
+	ParallelToolCalls *bool         `json:"parallel_tool_calls,omitempty"`
+}
+
+// Dataset is a set of training examples, written as a chat-format JSONL file.
+type Dataset struct {
+	Examples []Example
+
+	// CountTokens counts the tokens of a text, for validation and estimates.
+	CountTokens TokenCounter
+	// MaxExampleTokens is the largest size of an example.
+	MaxExampleTokens int
+}
+
+// New creates an empty dataset that estimates tokens with EstimateTokens.
+func New() *Dataset {
+	return &Dataset{
+		CountTokens:      EstimateTokens,
+		MaxExampleTokens: DefaultMaxExampleTokens,
+	}
+}
+
+// Add appends an example made of the messages of a conversation.
+func (d *Dataset) Add(messages ...openai.ChatCompletionMessage) {
+	d.Examples = append(d.Examples, Example{Messages: messages})
+}
+
+// AddExample appends an example.
+func (d *Dataset) AddExample(example Example) {
+	d.Examples = append(d.Examples, example)
+}
+
+// AddConversation appends the turns of a conversation as an example. Unlike Conversation.Messages,
+// the reasoning of assistant turns is kept, so that the model is trained on it.
+func (d *Dataset) AddConversation(conversation *openai.Conversation) {
+	messages := make([]openai.ChatCompletionMessage, len(conversation.Turns))
+	for i, turn := range conversation.Turns {
+		messages[i] = turn.Message
+	}
+	d.Add(messages...)
+}
+
+// MarshalJSONL returns the training file, one example per line.
+func (d *Dataset) MarshalJSONL() ([]byte, error) {
+	var buf bytes.Buffer
+	for i, example := range d.Examples {
+		data, err := json.Marshal(example)
+		if err != nil {
+			return nil, fmt.Errorf("example %d: %w", i, err)
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// Upload validates the dataset and uploads it as a fine-tuning file. If the dataset is invalid,
+// nothing is uploaded and a *ValidationError is returned.
+func (d *Dataset) Upload(ctx context.Context, client *openai.Client, name string) (openai.File, error) {
+	if issues := d.Validate(); len(issues) > 0 {
+		return openai.File{}, &ValidationError{Issues: issues}
+	}
+
+	data, err := d.MarshalJSONL()
+	if err != nil {
+		return openai.File{}, err
+	}
+	return client.CreateFileBytes(ctx, openai.FileBytesRequest{
+		Name:    name,
+		Bytes:   data,
+		Purpose: openai.PurposeFineTune,
+	})
+}
@@ -0,0 +1,135 @@
+package dataset_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/neospace-ai/go-openai"
+	"github.com/neospace-ai/go-openai/dataset"
+	"github.com/neospace-ai/go-openai/internal/test"
+	"github.com/neospace-ai/go-openai/internal/test/checks"
+)
+
+func TestMarshalJSONL(t *testing.T) {
+	conversation := openai.NewConversation("c1")
+	conversation.AddUser("What is 2+2?")
+	conversation.AddAssistant(openai.ChatCompletionMessage{
+		Role:      openai.ChatMessageRoleAssistant,
+		Content:   "4",
+		Reasoning: "2+2 is 4.",
+	}, openai.TaskResultCollection{})
+
+	d := dataset.New()
+	d.AddConversation(conversation)
+	d.AddExample(dataset.Example{
+		Messages: []openai.ChatCompletionMessage{
+			message(openai.ChatMessageRoleUser, "Look it up"),
+			toolCallMessage("call_1"),
+			toolMessage("call_1"),
+			message(openai.ChatMessageRoleAssistant, "Found it"),
+		},
+		Tools: []openai.Tool{{Type: openai.ToolTypeFunction, Function: &openai.FunctionDefinition{Name: "lookup"}}},
+	})
+
+	data, err := d.MarshalJSONL()
+	checks.NoError(t, err, "MarshalJSONL error")
+	lines := bytes.Split(bytes.TrimSpace(data), []byte("\n"))
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(lines))
+	}
+
+	var example dataset.Example
+	checks.NoError(t, json.Unmarshal(lines[0], &example), "Unmarshal error")
+	if len(example.Messages) != 2 || example.Messages[1].Reasoning != "2+2 is 4." {
+		t.Errorf("reasoning was not kept: %s", lines[0])
+	}
+	checks.NoError(t, json.Unmarshal(lines[1], &example), "Unmarshal error")
+	if len(example.Tools) != 1 || example.Messages[1].ToolCalls[0].ID != "call_1" ||
+		example.Messages[2].ToolCallID != "call_1" {
+		t.Errorf("tool calls were not kept: %s", lines[1])
+	}
+}
+
+func TestEstimate(t *testing.T) {
+	d := dataset.New()
+	d.CountTokens = func(text string) int { return len(text) }
+	d.MaxExampleTokens = 40
+	d.Add(message(openai.ChatMessageRoleUser, "abcd"), message(openai.ChatMessageRoleAssistant, "ef"))
+	d.Add(
+		message(openai.ChatMessageRoleUser, "abcdefghijklmnopqrstuvwxyz"),
+		message(openai.ChatMessageRoleAssistant, "ef"),
+	)
+
+	// 3 for the reply, plus 3 and the role for every message.
+	small := 3 + (3 + 4 + 4) + (3 + 9 + 2)
+	estimate := d.Estimate(0, 2)
+	if estimate.Examples != 2 || estimate.Epochs != 25 {
+		t.Errorf("unexpected default epochs: %+v", estimate)
+	}
+	large := small + 22
+	if estimate.ExampleTokens != large || estimate.EpochTokens != small+40 {
+		t.Errorf("unexpected tokens: %+v, small example %d", estimate, small)
+	}
+	if estimate.TotalTokens != estimate.EpochTokens*25 || estimate.EpochCost != float64(estimate.EpochTokens)*2/1e6 {
+		t.Errorf("unexpected totals: %+v", estimate)
+	}
+}
+
+func TestDefaultEpochs(t *testing.T) {
+	for examples, want := range map[int]int{1: 25, 10: 10, 50: 3, 5000: 3, 10000: 2, 50000: 1} {
+		if got := dataset.DefaultEpochs(examples); got != want {
+			t.Errorf("DefaultEpochs(%d) = %d, want %d", examples, got, want)
+		}
+	}
+}
+
+func TestUpload(t *testing.T) {
+	server := test.NewTestServer()
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	// The test server checks the Authorization header rather than the x-api-key header the client sends.
+	config.HTTPClient = &http.Client{
+		Transport: &test.TokenRoundTripper{Token: test.GetTestToken(), Fallback: http.DefaultTransport},
+	}
+	client := openai.NewClientWithConfig(config)
+
+	var uploaded []byte
+	server.RegisterHandler("/v1/files", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("purpose") != string(openai.PurposeFineTune) {
+			t.Errorf("unexpected purpose %q", r.FormValue("purpose"))
+		}
+		file, _, err := r.FormFile("file")
+		checks.NoError(t, err, "FormFile error")
+		uploaded, _ = io.ReadAll(file)
+		fmt.Fprint(w, `{"id":"file_1","purpose":"fine-tune"}`)
+	})
+
+	d := dataset.New()
+	d.Add(message(openai.ChatMessageRoleUser, "Hi"))
+	_, err := d.Upload(context.Background(), client, "train.jsonl")
+	var validationErr *dataset.ValidationError
+	if !errors.As(err, &validationErr) || !errors.Is(validationErr.Issues[0], dataset.ErrNoAssistantMessage) {
+		t.Fatalf("Upload should refuse an invalid dataset, got %v", err)
+	}
+	if uploaded != nil {
+		t.Fatal("an invalid dataset was uploaded")
+	}
+
+	d.Add(message(openai.ChatMessageRoleUser, "Hi"), message(openai.ChatMessageRoleAssistant, "Hello"))
+	d.Examples = d.Examples[1:]
+	file, err := d.Upload(context.Background(), client, "train.jsonl")
+	checks.NoError(t, err, "Upload error")
+	expected, _ := d.MarshalJSONL()
+	if file.ID != "file_1" || !bytes.Equal(uploaded, expected) {
+		t.Errorf("unexpected upload %+v: %s", file, uploaded)
+	}
+}
@@ -0,0 +1,118 @@
+package dataset
+
+import (
+	"encoding/json"
+	"unicode/utf8"
+)
+
+const (
+	// Tokens added by the chat format around every message and before the reply.
+	tokensPerMessage = 3
+	tokensPerName    = 1
+	tokensPerReply   = 3
+
+	targetEpochs      = 3
+	minTargetExamples = 100
+	maxTargetExamples = 25000
+	minDefaultEpochs  = 1
+	maxDefaultEpochs  = 25
+)
+
+// TokenCounter counts the tokens of a text. Plug in a tokenizer of the model for exact counts.
+type TokenCounter func(text string) int
+
+// EstimateTokens approximates the number of tokens of a text as one token per four characters,
+// which is close to the tokenizers of the GPT models for English text.
+func EstimateTokens(text string) int {
+	return (utf8.RuneCountInString(text) + 3) / 4
+}
+
+// Estimate is the size and cost of a fine-tuning job on a dataset.
+type Estimate struct {
+	Examples int
+	// ExampleTokens are the tokens of the largest example.
+	ExampleTokens int
+	// EpochTokens are the tokens billed per epoch, with examples truncated to the token limit.
+	EpochTokens int
+	Epochs      int
+	// TotalTokens are the tokens billed for all epochs.
+	TotalTokens int
+	// EpochCost and TotalCost are in the currency of the price per million tokens.
+	EpochCost float64
+	TotalCost float64
+}
+
+// Estimate returns the tokens and cost of training on the dataset for a number of epochs, at a price
+// per million training tokens. When epochs is zero, the number of epochs the API picks by default for
+// the size of the dataset is used.
+func (d *Dataset) Estimate(epochs int, pricePerMillionTokens float64) Estimate {
+	if epochs <= 0 {
+		epochs = DefaultEpochs(len(d.Examples))
+	}
+	estimate := Estimate{
+		Examples: len(d.Examples),
+		Epochs:   epochs,
+	}
+	for _, example := range d.Examples {
+		tokens := d.ExampleTokens(example)
+		estimate.ExampleTokens = max(estimate.ExampleTokens, tokens)
+		estimate.EpochTokens += min(tokens, d.maxExampleTokens())
+	}
+	estimate.TotalTokens = estimate.EpochTokens * epochs
+	estimate.EpochCost = float64(estimate.EpochTokens) * pricePerMillionTokens / 1e6
+	estimate.TotalCost = estimate.EpochCost * float64(epochs)
+	return estimate
+}
+
+// DefaultEpochs returns the number of epochs the API trains for by default, which targets three
+// epochs over datasets between 100 and 25000 examples.
+func DefaultEpochs(examples int) int {
+	switch {
+	case examples <= 0:
+		return targetEpochs
+	case examples*targetEpochs < minTargetExamples:
+		return min(maxDefaultEpochs, minTargetExamples/examples)
+	case examples*targetEpochs > maxTargetExamples:
+		return max(minDefaultEpochs, maxTargetExamples/examples)
+	default:
+		return targetEpochs
+	}
+}
+
+// ExampleTokens counts the tokens of an example in the chat format, including the definitions of its tools.
+func (d *Dataset) ExampleTokens(example Example) int {
+	count := d.counter()
+	tokens := tokensPerReply
+	for _, message := range example.Messages {
+		tokens += tokensPerMessage + count(message.Role) + count(message.Content) + count(message.Reasoning)
+		for _, part := range message.MultiContent {
+			tokens += count(part.Text)
+		}
+		if message.Name != "" {
+			tokens += tokensPerName + count(message.Name)
+		}
+		for _, call := range message.ToolCalls {
+			tokens += count(call.Function.Name) + count(call.Function.Arguments)
+		}
+	}
+	if len(example.Tools) > 0 {
+		if data, err := json.Marshal(example.Tools); err == nil {
+			tokens += count(string(data))
+		}
+	}
+	return tokens
+}
+
+func (d *Dataset) counter() TokenCounter {
+	if d.CountTokens == nil {
+		return EstimateTokens
+	}
+	return d.CountTokens
+}
+
+func (d *Dataset) maxExampleTokens() int {
+	if d.MaxExampleTokens <= 0 {
+		return DefaultMaxExampleTokens
+	}
+	return d.MaxExampleTokens
+}
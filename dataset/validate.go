@@ -0,0 +1,136 @@
+package dataset
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/neospace-ai/go-openai"
+)
+
+var (
+	ErrNoMessages          = errors.New("example has no messages")
+	ErrUnknownRole         = errors.New("unknown role")
+	ErrRoleOrder           = errors.New("message out of order")
+	ErrEmptyContent        = errors.New("message has no content")
+	ErrUnknownToolCall     = errors.New("tool message does not answer a tool call")
+	ErrUnansweredToolCalls = errors.New("tool calls have no tool message")
+	ErrNoAssistantMessage  = errors.New("example has no assistant message")
+	ErrTooManyTokens       = errors.New("example exceeds the token limit")
+)
+
+// Issue is a problem found in an example. It matches one of the Err variables with errors.Is.
+type Issue struct {
+	// Example is the index of the example.
+	Example int
+	// Message is the index of the message in the example, or -1 for issues with the whole example.
+	Message int
+	Err     error
+}
+
+func (i Issue) Error() string {
+	if i.Message < 0 {
+		return fmt.Sprintf("example %d: %s", i.Example, i.Err)
+	}
+	return fmt.Sprintf("example %d, message %d: %s", i.Example, i.Message, i.Err)
+}
+
+func (i Issue) Unwrap() error {
+	return i.Err
+}
+
+// ValidationError is returned when uploading an invalid dataset.
+type ValidationError struct {
+	Issues []Issue
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Issues) == 1 {
+		return fmt.Sprintf("invalid dataset: %s", e.Issues[0])
+	}
+	return fmt.Sprintf("invalid dataset: %s (and %d more issues)", e.Issues[0], len(e.Issues)-1)
+}
+
+// Validate checks every example and returns the issues found, in order.
+//
+// An example starts with an optional system message, then alternates between user messages and
+// assistant messages. An assistant message with tool calls is followed by a tool message for each
+// call before the conversation goes on. Every message has content, except assistant messages with
+// tool calls, and an example has at least one assistant message and fits in MaxExampleTokens.
+func (d *Dataset) Validate() []Issue {
+	var issues []Issue
+	for i, example := range d.Examples {
+		issues = append(issues, d.validateExample(i, example)...)
+	}
+	return issues
+}
+
+func (d *Dataset) validateExample(index int, example Example) []Issue {
+	var issues []Issue
+	report := func(message int, err error) {
+		issues = append(issues, Issue{Example: index, Message: message, Err: err})
+	}
+	if len(example.Messages) == 0 {
+		report(-1, ErrNoMessages)
+		return issues
+	}
+
+	var (
+		previous     string
+		pendingCalls map[string]bool
+		hasAssistant bool
+	)
+	for i, message := range example.Messages {
+		switch message.Role {
+		case openai.ChatMessageRoleSystem:
+			if i > 0 {
+				report(i, fmt.Errorf("%w: system message after the start", ErrRoleOrder))
+			}
+		case openai.ChatMessageRoleUser:
+			if previous == openai.ChatMessageRoleUser {
+				report(i, fmt.Errorf("%w: consecutive user messages", ErrRoleOrder))
+			}
+		case openai.ChatMessageRoleAssistant:
+			hasAssistant = true
+			if previous != openai.ChatMessageRoleUser && previous != openai.ChatMessageRoleTool {
+				report(i, fmt.Errorf("%w: assistant message does not follow a user or tool message", ErrRoleOrder))
+			}
+		case openai.ChatMessageRoleTool:
+			if !pendingCalls[message.ToolCallID] {
+				report(i, fmt.Errorf("%w: %q", ErrUnknownToolCall, message.ToolCallID))
+			}
+			delete(pendingCalls, message.ToolCallID)
+		default:
+			report(i, fmt.Errorf("%w: %q", ErrUnknownRole, message.Role))
+		}
+
+		if message.Role != openai.ChatMessageRoleTool && len(pendingCalls) > 0 {
+			report(i, fmt.Errorf("%w: %d calls", ErrUnansweredToolCalls, len(pendingCalls)))
+			pendingCalls = nil
+		}
+		if len(message.ToolCalls) > 0 {
+			pendingCalls = make(map[string]bool, len(message.ToolCalls))
+			for _, call := range message.ToolCalls {
+				pendingCalls[call.ID] = true
+			}
+		} else if !messageHasContent(message) {
+			report(i, ErrEmptyContent)
+		}
+		previous = message.Role
+	}
+	if len(pendingCalls) > 0 {
+		report(len(example.Messages)-1, fmt.Errorf("%w: %d calls", ErrUnansweredToolCalls, len(pendingCalls)))
+	}
+
+	if !hasAssistant {
+		report(-1, ErrNoAssistantMessage)
+	}
+	if tokens := d.ExampleTokens(example); tokens > d.maxExampleTokens() {
+		report(-1, fmt.Errorf("%w: %d tokens, limit %d", ErrTooManyTokens, tokens, d.maxExampleTokens()))
+	}
+	return issues
+}
+
+// messageHasContent reports whether a message has text or content parts.
+func messageHasContent(message openai.ChatCompletionMessage) bool {
+	return message.Content != "" || len(message.MultiContent) > 0
+}
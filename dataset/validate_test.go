@@ -0,0 +1,126 @@
+package dataset_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/neospace-ai/go-openai"
+	"github.com/neospace-ai/go-openai/dataset"
+)
+
+func message(role, content string) openai.ChatCompletionMessage {
+	return openai.ChatCompletionMessage{Role: role, Content: content}
+}
+
+func toolCallMessage(ids ...string) openai.ChatCompletionMessage {
+	msg := openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant}
+	for _, id := range ids {
+		msg.ToolCalls = append(msg.ToolCalls, openai.ToolCall{
+			ID:       id,
+			Type:     openai.ToolTypeFunction,
+			Function: openai.FunctionCall{Name: "lookup", Arguments: `{"q":"x"}`},
+		})
+	}
+	return msg
+}
+
+func toolMessage(id string) openai.ChatCompletionMessage {
+	return openai.ChatCompletionMessage{Role: openai.ChatMessageRoleTool, Content: "result", ToolCallID: id}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		messages []openai.ChatCompletionMessage
+		want     []error
+	}{
+		{
+			name: "valid",
+			messages: []openai.ChatCompletionMessage{
+				message(openai.ChatMessageRoleSystem, "You are helpful."),
+				message(openai.ChatMessageRoleUser, "Hi"),
+				message(openai.ChatMessageRoleAssistant, "Hello"),
+			},
+		},
+		{
+			name: "valid tool calls",
+			messages: []openai.ChatCompletionMessage{
+				message(openai.ChatMessageRoleUser, "Look it up"),
+				toolCallMessage("call_1", "call_2"),
+				toolMessage("call_2"),
+				toolMessage("call_1"),
+				message(openai.ChatMessageRoleAssistant, "Found it"),
+			},
+		},
+		{
+			name: "no messages",
+			want: []error{dataset.ErrNoMessages},
+		},
+		{
+			name: "unknown role and no assistant",
+			messages: []openai.ChatCompletionMessage{
+				message(openai.ChatMessageRoleUser, "Hi"),
+				message("robot", "Hello"),
+			},
+			want: []error{dataset.ErrUnknownRole, dataset.ErrNoAssistantMessage},
+		},
+		{
+			name: "role order",
+			messages: []openai.ChatCompletionMessage{
+				message(openai.ChatMessageRoleAssistant, "Hello"),
+				message(openai.ChatMessageRoleUser, "Hi"),
+				message(openai.ChatMessageRoleUser, "Hi again"),
+				message(openai.ChatMessageRoleSystem, "Late system"),
+			},
+			want: []error{dataset.ErrRoleOrder, dataset.ErrRoleOrder, dataset.ErrRoleOrder},
+		},
+		{
+			name: "empty content",
+			messages: []openai.ChatCompletionMessage{
+				message(openai.ChatMessageRoleUser, ""),
+				message(openai.ChatMessageRoleAssistant, "Hello"),
+			},
+			want: []error{dataset.ErrEmptyContent},
+		},
+		{
+			name: "tool call mismatch",
+			messages: []openai.ChatCompletionMessage{
+				message(openai.ChatMessageRoleUser, "Look it up"),
+				toolCallMessage("call_1"),
+				toolMessage("call_2"),
+				message(openai.ChatMessageRoleAssistant, "Found it"),
+			},
+			want: []error{dataset.ErrUnknownToolCall, dataset.ErrUnansweredToolCalls},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := dataset.New()
+			d.Add(tt.messages...)
+			issues := d.Validate()
+			if len(issues) != len(tt.want) {
+				t.Fatalf("Validate() = %v, want %v", issues, tt.want)
+			}
+			for i, issue := range issues {
+				if !errors.Is(issue, tt.want[i]) {
+					t.Errorf("issue %d = %v, want %v", i, issue, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateTokenLimit(t *testing.T) {
+	d := dataset.New()
+	d.MaxExampleTokens = 100
+	d.Add(
+		message(openai.ChatMessageRoleUser, strings.Repeat("word ", 100)),
+		message(openai.ChatMessageRoleAssistant, "ok"),
+	)
+
+	issues := d.Validate()
+	if len(issues) != 1 || !errors.Is(issues[0], dataset.ErrTooManyTokens) || issues[0].Message != -1 {
+		t.Errorf("Validate() = %v, want a token limit issue", issues)
+	}
+}
@@ -0,0 +1,366 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"unicode/utf8"
+)
+
+const (
+	defaultEmbeddingMaxChunkTokens = 8191
+	defaultEmbeddingMaxBatchInputs = 2048
+	defaultEmbeddingMaxBatchTokens = 300000
+	defaultEmbeddingConcurrency    = 4
+)
+
+var (
+	ErrEmbeddingChunkOverlap = errors.New("chunk overlap is not smaller than the chunk size")
+	ErrEmbeddingNoTokens     = errors.New("tokenizer returned no tokens for a non-empty text")
+)
+
+// EmbeddingTokenizer converts texts to the tokens of an embedding model and back, like the
+// cl100k_base encoding for the text-embedding-3 models.
+type EmbeddingTokenizer interface {
+	Encode(text string) []int
+	Decode(tokens []int) string
+}
+
+// EmbeddingBatcher embeds any number of texts of any length. It splits long texts into chunks that
+// fit the model, packs the chunks into requests under the limits of the API, sends the requests
+// concurrently and returns the embeddings in the order of the texts.
+type EmbeddingBatcher struct {
+	client *Client
+
+	// Model, Dimensions and User are set on every request.
+	Model      EmbeddingModel
+	Dimensions int
+	User       string
+
+	// Tokenizer splits texts into tokens, which are then sent instead of the texts. Without a
+	// tokenizer, every byte of a text is counted as a token, the most a byte-level tokenizer can
+	// produce whatever the script, so that chunks stay within the limits. Chunks are then about four
+	// times shorter than needed for English text. Embed fails with ErrEmbeddingNoTokens when the
+	// tokenizer returns no tokens for a non-empty text.
+	Tokenizer EmbeddingTokenizer
+	// MaxChunkTokens is the maximum number of tokens of a chunk. Consecutive chunks of a text share
+	// ChunkOverlap tokens, so that the text around chunk boundaries is embedded in context.
+	MaxChunkTokens int
+	ChunkOverlap   int
+
+	// MaxBatchInputs and MaxBatchTokens are the maximum number of chunks and tokens of a request.
+	MaxBatchInputs int
+	MaxBatchTokens int
+	// Concurrency is the maximum number of requests in flight.
+	Concurrency int
+
+	// Average combines the chunks of a text into a single embedding, the average of the chunk
+	// embeddings weighted by their number of tokens and normalized.
+	Average bool
+}
+
+// NewEmbeddingBatcher creates an EmbeddingBatcher for a model with the limits of the API.
+func NewEmbeddingBatcher(client *Client, model EmbeddingModel) *EmbeddingBatcher {
+	return &EmbeddingBatcher{
+		client:         client,
+		Model:          model,
+		MaxChunkTokens: defaultEmbeddingMaxChunkTokens,
+		MaxBatchInputs: defaultEmbeddingMaxBatchInputs,
+		MaxBatchTokens: defaultEmbeddingMaxBatchTokens,
+		Concurrency:    defaultEmbeddingConcurrency,
+	}
+}
+
+// EmbeddingChunk is the embedding of a part of a text.
+type EmbeddingChunk struct {
+	Text      string
+	Tokens    int
+	Embedding []float32
+}
+
+// TextEmbedding holds the embeddings of a text.
+type TextEmbedding struct {
+	// Chunks are the embeddings of the parts of the text, in order. There is a single chunk for
+	// texts shorter than MaxChunkTokens, and none for empty texts, which are not sent.
+	Chunks []EmbeddingChunk
+	// Embedding is the embedding of the whole text, set when the text has a single chunk or when
+	// the chunks are averaged.
+	Embedding []float32
+}
+
+// EmbeddingBatchResponse holds the embeddings of the texts, in the order of the texts, and the
+// usage of all the requests.
+type EmbeddingBatchResponse struct {
+	Data  []TextEmbedding
+	Model EmbeddingModel
+	Usage Usage
+}
+
+// embeddingChunk is a chunk of a text before it is embedded.
+type embeddingChunk struct {
+	text   int
+	chunk  int
+	tokens []int
+	EmbeddingChunk
+}
+
+// Embed embeds texts. If a request fails, the requests in flight are cancelled and the error is returned.
+func (b *EmbeddingBatcher) Embed(ctx context.Context, texts []string) (EmbeddingBatchResponse, error) {
+	maxChunkTokens := b.MaxChunkTokens
+	if maxChunkTokens <= 0 {
+		maxChunkTokens = defaultEmbeddingMaxChunkTokens
+	}
+	overlap := max(b.ChunkOverlap, 0)
+	if overlap >= maxChunkTokens {
+		return EmbeddingBatchResponse{}, fmt.Errorf("%w: %d tokens, chunk size %d",
+			ErrEmbeddingChunkOverlap, overlap, maxChunkTokens)
+	}
+
+	response := EmbeddingBatchResponse{Data: make([]TextEmbedding, len(texts)), Model: b.Model}
+	var chunks []*embeddingChunk
+	for i, text := range texts {
+		textChunks, err := b.chunkText(text, maxChunkTokens, overlap)
+		if err != nil {
+			return EmbeddingBatchResponse{}, fmt.Errorf("text %d: %w", i, err)
+		}
+		response.Data[i].Chunks = make([]EmbeddingChunk, len(textChunks))
+		for j := range textChunks {
+			textChunks[j].text, textChunks[j].chunk = i, j
+			chunks = append(chunks, &textChunks[j])
+		}
+	}
+
+	if err := b.embedBatches(ctx, b.batches(chunks), &response); err != nil {
+		return EmbeddingBatchResponse{}, err
+	}
+
+	for _, chunk := range chunks {
+		response.Data[chunk.text].Chunks[chunk.chunk] = chunk.EmbeddingChunk
+	}
+	for i := range response.Data {
+		embedding := &response.Data[i]
+		switch {
+		case len(embedding.Chunks) == 1:
+			embedding.Embedding = embedding.Chunks[0].Embedding
+		case len(embedding.Chunks) > 1 && b.Average:
+			embedding.Embedding = averageChunks(embedding.Chunks)
+		}
+	}
+	return response, nil
+}
+
+// chunkText splits a text into chunks of at most maxTokens tokens, consecutive chunks sharing overlap tokens.
+func (b *EmbeddingBatcher) chunkText(text string, maxTokens, overlap int) ([]embeddingChunk, error) {
+	if text == "" {
+		return nil, nil
+	}
+
+	var (
+		tokens []int
+		count  int
+	)
+	if b.Tokenizer != nil {
+		tokens = b.Tokenizer.Encode(text)
+		count = len(tokens)
+		if count == 0 {
+			// The chunk would be sent as an empty input, which the API rejects.
+			return nil, ErrEmbeddingNoTokens
+		}
+	} else {
+		count = len(text)
+	}
+
+	var chunks []embeddingChunk
+	for start := 0; ; {
+		end := min(start+maxTokens, count)
+		if b.Tokenizer == nil {
+			end = runeStart(text, end)
+			if end <= start {
+				// A character longer than the chunk size is kept whole.
+				_, size := utf8.DecodeRuneInString(text[start:])
+				end = start + size
+			}
+		}
+
+		chunk := embeddingChunk{EmbeddingChunk: EmbeddingChunk{Tokens: end - start}}
+		if b.Tokenizer != nil {
+			chunk.tokens = tokens[start:end]
+			chunk.Text = b.Tokenizer.Decode(chunk.tokens)
+		} else {
+			chunk.Text = text[start:end]
+		}
+		chunks = append(chunks, chunk)
+		if end == count {
+			return chunks, nil
+		}
+
+		next := end - overlap
+		if b.Tokenizer == nil {
+			next = runeStart(text, next)
+		}
+		if next <= start {
+			// The overlap does not fit after the characters kept whole.
+			next = end
+		}
+		start = next
+	}
+}
+
+// runeStart returns the offset of the character of text at or before offset.
+func runeStart(text string, offset int) int {
+	for offset > 0 && offset < len(text) && !utf8.RuneStart(text[offset]) {
+		offset--
+	}
+	return offset
+}
+
+// batches packs chunks into batches of at most MaxBatchInputs chunks and MaxBatchTokens tokens, in order.
+func (b *EmbeddingBatcher) batches(chunks []*embeddingChunk) [][]*embeddingChunk {
+	maxInputs := b.MaxBatchInputs
+	if maxInputs <= 0 {
+		maxInputs = defaultEmbeddingMaxBatchInputs
+	}
+	maxTokens := b.MaxBatchTokens
+	if maxTokens <= 0 {
+		maxTokens = defaultEmbeddingMaxBatchTokens
+	}
+
+	var (
+		batches [][]*embeddingChunk
+		start   int
+		tokens  int
+	)
+	for i, chunk := range chunks {
+		if i > start && (i-start == maxInputs || tokens+chunk.Tokens > maxTokens) {
+			batches = append(batches, chunks[start:i])
+			start, tokens = i, 0
+		}
+		tokens += chunk.Tokens
+	}
+	if start < len(chunks) {
+		batches = append(batches, chunks[start:])
+	}
+	return batches
+}
+
+// embedBatches sends the batches concurrently, sets the embeddings of their chunks and adds up the usage.
+func (b *EmbeddingBatcher) embedBatches(
+	ctx context.Context,
+	batches [][]*embeddingChunk,
+	response *EmbeddingBatchResponse,
+) error {
+	concurrency := b.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	batchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr == nil {
+			firstErr = err
+			cancel()
+		}
+	}
+
+	sem := make(chan struct{}, concurrency)
+	for index, batch := range batches {
+		select {
+		case <-batchCtx.Done():
+		case sem <- struct{}{}:
+		}
+		if batchCtx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			res, err := b.embedBatch(batchCtx, batch)
+			if err != nil {
+				fail(fmt.Errorf("embedding batch %d: %w", index, err))
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if res.Model != "" {
+				response.Model = res.Model
+			}
+			response.Usage.PromptTokens += res.Usage.PromptTokens
+			response.Usage.TotalTokens += res.Usage.TotalTokens
+		}()
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return firstErr
+}
+
+func (b *EmbeddingBatcher) embedBatch(ctx context.Context, batch []*embeddingChunk) (EmbeddingResponse, error) {
+	var request EmbeddingRequestConverter
+	if b.Tokenizer != nil {
+		input := make([][]int, len(batch))
+		for i, chunk := range batch {
+			input[i] = chunk.tokens
+		}
+		request = EmbeddingRequestTokens{Input: input, Model: b.Model, User: b.User, Dimensions: b.Dimensions}
+	} else {
+		input := make([]string, len(batch))
+		for i, chunk := range batch {
+			input[i] = chunk.Text
+		}
+		request = EmbeddingRequestStrings{Input: input, Model: b.Model, User: b.User, Dimensions: b.Dimensions}
+	}
+
+	res, err := b.client.CreateEmbeddings(ctx, request)
+	if err != nil {
+		return res, err
+	}
+	if len(res.Data) != len(batch) {
+		return res, fmt.Errorf("got %d embeddings for %d inputs", len(res.Data), len(batch))
+	}
+	for _, embedding := range res.Data {
+		if embedding.Index < 0 || embedding.Index >= len(batch) {
+			return res, fmt.Errorf("got an embedding for input %d of %d", embedding.Index, len(batch))
+		}
+		batch[embedding.Index].Embedding = embedding.Embedding
+	}
+	return res, nil
+}
+
+// averageChunks returns the average of the chunk embeddings weighted by their tokens, normalized.
+func averageChunks(chunks []EmbeddingChunk) []float32 {
+	sum := make([]float64, len(chunks[0].Embedding))
+	for _, chunk := range chunks {
+		for i, v := range chunk.Embedding[:min(len(sum), len(chunk.Embedding))] {
+			sum[i] += float64(v) * float64(chunk.Tokens)
+		}
+	}
+
+	var norm float64
+	for _, v := range sum {
+		norm += v * v
+	}
+	norm = math.Sqrt(norm)
+	average := make([]float32, len(sum))
+	for i, v := range sum {
+		if norm > 0 {
+			v /= norm
+		}
+		average[i] = float32(v)
+	}
+	return average
+}
@@ -0,0 +1,284 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/neospace-ai/go-openai"
+	"github.com/neospace-ai/go-openai/internal/test/checks"
+)
+
+// embeddingBatchServer answers embedding requests with the vector [length of the input, 1], listing
+// the embeddings in reverse order, and records the inputs of every request.
+type embeddingBatchServer struct {
+	mu       sync.Mutex
+	requests [][]any
+	failOn   string
+}
+
+func (s *embeddingBatchServer) handle(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Input []json.RawMessage `json:"input"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response := openai.EmbeddingResponse{Model: openai.SmallEmbedding3}
+	var inputs []any
+	for i := len(request.Input) - 1; i >= 0; i-- {
+		var text string
+		var tokens []int
+		length := 0
+		if err := json.Unmarshal(request.Input[i], &text); err == nil {
+			if s.failOn != "" && strings.Contains(text, s.failOn) {
+				http.Error(w, `{"error": {"message": "boom"}}`, http.StatusInternalServerError)
+				return
+			}
+			inputs = append(inputs, text)
+			length = len(text)
+		} else if err = json.Unmarshal(request.Input[i], &tokens); err == nil {
+			inputs = append(inputs, tokens)
+			length = len(tokens)
+		}
+		response.Data = append(response.Data, openai.Embedding{Index: i, Embedding: []float32{float32(length), 1}})
+		response.Usage.PromptTokens += length
+		response.Usage.TotalTokens += length
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, inputs)
+	s.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func TestEmbeddingBatcher(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	embeddings := &embeddingBatchServer{}
+	server.RegisterHandler("/v1/embeddings", embeddings.handle)
+
+	var words []string
+	for i := 0; i < 20; i++ {
+		words = append(words, fmt.Sprintf("word%d", i))
+	}
+	long := strings.Join(words, " ")
+	texts := []string{"hi", long, "", "dolor"}
+	batcher := openai.NewEmbeddingBatcher(client, openai.SmallEmbedding3)
+	batcher.MaxChunkTokens = 8
+	batcher.ChunkOverlap = 2
+	batcher.MaxBatchInputs = 3
+	batcher.Concurrency = 2
+	response, err := batcher.Embed(context.Background(), texts)
+	checks.NoError(t, err, "Embed error")
+
+	if len(response.Data) != len(texts) || response.Model != openai.SmallEmbedding3 {
+		t.Fatalf("got %d embeddings of model %s, want %d of %s",
+			len(response.Data), response.Model, len(texts), openai.SmallEmbedding3)
+	}
+	for i, text := range texts {
+		embedding := response.Data[i]
+		if text == "" {
+			if len(embedding.Chunks) != 0 || embedding.Embedding != nil {
+				t.Errorf("empty text has embeddings %+v", embedding)
+			}
+			continue
+		}
+
+		var rebuilt string
+		for j, chunk := range embedding.Chunks {
+			if chunk.Tokens > 8 || chunk.Tokens == 0 {
+				t.Errorf("text %d chunk %d has %d tokens", i, j, chunk.Tokens)
+			}
+			if chunk.Embedding[0] != float32(len(chunk.Text)) {
+				t.Errorf("text %d chunk %d %q has the embedding %v of another chunk", i, j, chunk.Text, chunk.Embedding)
+			}
+			rebuilt = joinOverlapping(t, rebuilt, chunk.Text)
+		}
+		if rebuilt != text {
+			t.Errorf("chunks of text %d rebuild %q, want %q", i, rebuilt, text)
+		}
+	}
+
+	if n := len(response.Data[1].Chunks); n < 2 {
+		t.Fatalf("long text has %d chunks, want several", n)
+	}
+	if response.Data[1].Embedding != nil {
+		t.Errorf("long text has an embedding %v without averaging", response.Data[1].Embedding)
+	}
+	if got := response.Data[0].Embedding; len(got) != 2 || got[0] != 2 {
+		t.Errorf("short text embedding = %v, want [2 1]", got)
+	}
+
+	var inputs, tokens int
+	for _, request := range embeddings.requests {
+		if len(request) > 3 {
+			t.Errorf("request has %d inputs, want at most 3", len(request))
+		}
+		inputs += len(request)
+		for _, input := range request {
+			tokens += len(input.(string))
+		}
+	}
+	if inputs != len(response.Data[1].Chunks)+2 {
+		t.Errorf("sent %d inputs, want %d", inputs, len(response.Data[1].Chunks)+2)
+	}
+	if response.Usage.PromptTokens != tokens || response.Usage.TotalTokens != tokens {
+		t.Errorf("usage = %+v, want %d tokens", response.Usage, tokens)
+	}
+}
+
+// joinOverlapping appends a chunk to the text rebuilt so far, dropping the start of the chunk the
+// previous chunk ends with.
+func joinOverlapping(t *testing.T, rebuilt, chunk string) string {
+	t.Helper()
+	for overlap := min(len(rebuilt), len(chunk)); overlap >= 0; overlap-- {
+		if strings.HasSuffix(rebuilt, chunk[:overlap]) && (overlap > 0 || rebuilt == "") {
+			return rebuilt + chunk[overlap:]
+		}
+	}
+	t.Errorf("chunk %q does not overlap %q", chunk, rebuilt)
+	return rebuilt + chunk
+}
+
+// wordTokenizer has a token per word.
+type wordTokenizer struct{}
+
+func (wordTokenizer) Encode(text string) []int {
+	var tokens []int
+	for _, word := range strings.Fields(text) {
+		n, _ := strconv.Atoi(word)
+		tokens = append(tokens, n)
+	}
+	return tokens
+}
+
+func (wordTokenizer) Decode(tokens []int) string {
+	words := make([]string, len(tokens))
+	for i, token := range tokens {
+		words[i] = strconv.Itoa(token)
+	}
+	return strings.Join(words, " ")
+}
+
+func TestEmbeddingBatcherMultibyteText(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	embeddings := &embeddingBatchServer{}
+	server.RegisterHandler("/v1/embeddings", embeddings.handle)
+
+	// Distinct characters of three and four bytes, which tokenizers may split into a token per byte,
+	// around the default chunk size.
+	text := func(n int, f func(i int) string) string {
+		var b strings.Builder
+		for i := 0; i < n; i++ {
+			b.WriteString(f(i))
+		}
+		return b.String()
+	}
+	cjk := func(i int) string { return string(rune(0x4E00 + i)) }
+	texts := []string{
+		text(2730, cjk),
+		text(2731, cjk),
+		text(2100, func(i int) string { return string(rune(0x20000 + i)) }),
+		text(1200, func(i int) string { return "a" + cjk(i) + string(rune(0x20000+i)) }),
+	}
+	batcher := openai.NewEmbeddingBatcher(client, openai.SmallEmbedding3)
+	batcher.ChunkOverlap = 100
+	response, err := batcher.Embed(context.Background(), texts)
+	checks.NoError(t, err, "Embed error")
+
+	for i, text := range texts {
+		var rebuilt string
+		for j, chunk := range response.Data[i].Chunks {
+			if len(chunk.Text) > 8191 || chunk.Tokens != len(chunk.Text) || !utf8.ValidString(chunk.Text) {
+				t.Errorf("text %d chunk %d has %d bytes and %d tokens", i, j, len(chunk.Text), chunk.Tokens)
+			}
+			rebuilt = joinOverlapping(t, rebuilt, chunk.Text)
+		}
+		if rebuilt != text {
+			t.Errorf("chunks of text %d do not rebuild it", i)
+		}
+	}
+	if n := len(response.Data[0].Chunks); n != 1 {
+		t.Errorf("text of 8190 bytes has %d chunks, want 1", n)
+	}
+	if n := len(response.Data[1].Chunks); n != 2 {
+		t.Errorf("text of 8193 bytes has %d chunks, want 2", n)
+	}
+}
+
+func TestEmbeddingBatcherTokenizerAndAverage(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	embeddings := &embeddingBatchServer{}
+	server.RegisterHandler("/v1/embeddings", embeddings.handle)
+
+	batcher := openai.NewEmbeddingBatcher(client, openai.SmallEmbedding3)
+	batcher.Tokenizer = wordTokenizer{}
+	batcher.MaxChunkTokens = 4
+	batcher.ChunkOverlap = 1
+	batcher.MaxBatchTokens = 6
+	batcher.Concurrency = 1
+	batcher.Average = true
+	response, err := batcher.Embed(context.Background(), []string{"1 2 3 4 5 6", "7"})
+	checks.NoError(t, err, "Embed error")
+
+	chunks := response.Data[0].Chunks
+	if len(chunks) != 2 || chunks[0].Text != "1 2 3 4" || chunks[1].Text != "4 5 6" {
+		t.Fatalf("chunks = %+v, want 1 2 3 4 and 4 5 6", chunks)
+	}
+	// The chunks are embedded as [4 1] and [3 1], weighted by 4 and 3 tokens.
+	x, y := 4.0*4+3*3, 4.0+3
+	norm := math.Hypot(x, y)
+	got := response.Data[0].Embedding
+	if math.Abs(float64(got[0])-x/norm) > 1e-6 || math.Abs(float64(got[1])-y/norm) > 1e-6 {
+		t.Errorf("average = %v, want [%v %v]", got, x/norm, y/norm)
+	}
+	if got := response.Data[1].Embedding; len(got) != 2 || got[0] != 1 {
+		t.Errorf("single chunk embedding = %v, want [1 1]", got)
+	}
+
+	want := fmt.Sprint([][]any{{[]int{1, 2, 3, 4}}, {[]int{7}, []int{4, 5, 6}}})
+	if got := fmt.Sprint(embeddings.requests); got != want {
+		t.Errorf("requests = %s, want %s", got, want)
+	}
+}
+
+func TestEmbeddingBatcherErrors(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	embeddings := &embeddingBatchServer{failOn: "fail"}
+	server.RegisterHandler("/v1/embeddings", embeddings.handle)
+
+	batcher := openai.NewEmbeddingBatcher(client, openai.SmallEmbedding3)
+	batcher.MaxBatchInputs = 1
+	_, err := batcher.Embed(context.Background(), []string{"ok", "fail", "ok"})
+	checks.HasError(t, err, "Embed should fail when a request fails")
+	if !strings.Contains(err.Error(), "embedding batch 1") {
+		t.Errorf("error = %v, want it to name batch 1", err)
+	}
+
+	batcher.MaxChunkTokens = 10
+	batcher.ChunkOverlap = 10
+	_, err = batcher.Embed(context.Background(), []string{"ok"})
+	checks.ErrorIs(t, err, openai.ErrEmbeddingChunkOverlap, "Embed should fail on an overlap as large as chunks")
+
+	batcher.ChunkOverlap = 0
+	batcher.Tokenizer = wordTokenizer{}
+	_, err = batcher.Embed(context.Background(), []string{"1 2", " \n "})
+	checks.ErrorIs(t, err, openai.ErrEmbeddingNoTokens, "Embed should fail on a text without tokens")
+	if !strings.Contains(err.Error(), "text 1") {
+		t.Errorf("error = %v, want it to name text 1", err)
+	}
+}
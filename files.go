@@ -4,14 +4,19 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
+
+	utils "github.com/neospace-ai/go-openai/internal"
 )
 
 type FileRequest struct {
 	FileName string `json:"file"`
 	FilePath string `json:"-"`
 	Purpose  string `json:"purpose"`
+	// Progress is called while the file is uploaded
+	Progress UploadProgressFunc `json:"-"`
 }
 
 // PurposeType represents the purpose of the file when uploading.
@@ -33,6 +38,8 @@ type FileBytesRequest struct {
 	Bytes []byte
 	// the purpose of the file
 	Purpose PurposeType
+	// Progress is called while the file is uploaded
+	Progress UploadProgressFunc
 }
 
 // File struct represents an OpenAPI file.
@@ -56,71 +63,62 @@ type FilesList struct {
 	httpHeader
 }
 
+// FileReaderRequest represents a file upload request streamed from a reader.
+type FileReaderRequest struct {
+	// the name of the uploaded file in OpenAI
+	Name string
+	// the contents of the file, read while they are uploaded
+	Reader io.Reader
+	// the purpose of the file
+	Purpose PurposeType
+	// Progress is called while the file is uploaded
+	Progress UploadProgressFunc
+}
+
 // CreateFileBytes uploads bytes directly to OpenAI without requiring a local file.
 func (c *Client) CreateFileBytes(ctx context.Context, request FileBytesRequest) (file File, err error) {
-	var b bytes.Buffer
-	reader := bytes.NewReader(request.Bytes)
-	builder := c.createFormBuilder(&b)
-
-	err = builder.WriteField("purpose", string(request.Purpose))
-	if err != nil {
-		return
-	}
-
-	err = builder.CreateFormFileReader("file", reader, request.Name)
-	if err != nil {
-		return
-	}
-
-	err = builder.Close()
-	if err != nil {
-		return
-	}
-
-	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL("/files"),
-		withBody(&b), withContentType(builder.FormDataContentType()))
-	if err != nil {
-		return
-	}
+	return c.CreateFileReader(ctx, FileReaderRequest{
+		Name:     request.Name,
+		Reader:   bytes.NewReader(request.Bytes),
+		Purpose:  request.Purpose,
+		Progress: request.Progress,
+	})
+}
 
-	err = c.sendRequest(req, &file)
+// CreateFileReader uploads the contents of a reader, streaming them without buffering the file.
+// The request is sent with a content length when the reader knows its size, like *bytes.Reader,
+// *strings.Reader and *os.File, and chunked otherwise.
+func (c *Client) CreateFileReader(ctx context.Context, request FileReaderRequest) (file File, err error) {
+	err = c.sendMultipartRequest(ctx, c.fullURL("/files"), request.Progress, func(builder utils.FormBuilder) error {
+		if err := builder.WriteField("purpose", string(request.Purpose)); err != nil {
+			return err
+		}
+		if err := builder.CreateFormFileReader("file", request.Reader, request.Name); err != nil {
+			return err
+		}
+		return builder.Close()
+	}, &file)
 	return
 }
 
 // CreateFile uploads a jsonl file to GPT3
 // FilePath must be a local file path.
 func (c *Client) CreateFile(ctx context.Context, request FileRequest) (file File, err error) {
-	var b bytes.Buffer
-	builder := c.createFormBuilder(&b)
-
-	err = builder.WriteField("purpose", request.Purpose)
-	if err != nil {
-		return
-	}
-
 	fileData, err := os.Open(request.FilePath)
 	if err != nil {
 		return
 	}
 	defer fileData.Close()
 
-	err = builder.CreateFormFile("file", fileData)
-	if err != nil {
-		return
-	}
-
-	err = builder.Close()
-	if err != nil {
-		return
-	}
-
-	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL("/files"),
-		withBody(&b), withContentType(builder.FormDataContentType()))
-	if err != nil {
-		return
-	}
-
-	err = c.sendRequest(req, &file)
+	err = c.sendMultipartRequest(ctx, c.fullURL("/files"), request.Progress, func(builder utils.FormBuilder) error {
+		if err := builder.WriteField("purpose", request.Purpose); err != nil {
+			return err
+		}
+		if err := builder.CreateFormFile("file", fileData); err != nil {
+			return err
+		}
+		return builder.Close()
+	}, &file)
 	return
 }
 
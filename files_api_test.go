@@ -9,7 +9,9 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 	"testing"
+	"testing/iotest"
 	"time"
 
 	"github.com/neospace-ai/go-openai"
@@ -42,6 +44,62 @@ func TestFileUpload(t *testing.T) {
 	checks.NoError(t, err, "CreateFile error")
 }
 
+func TestFileReaderUpload(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var contentLength int64
+	server.RegisterHandler("/v1/files", func(w http.ResponseWriter, r *http.Request) {
+		contentLength = r.ContentLength
+		handleCreateFile(w, r)
+	})
+
+	var sent, total int64
+	progress := func(s, t int64) { sent, total = s, t }
+
+	content := strings.Repeat("line\n", 100000)
+	file, err := client.CreateFileReader(context.Background(), openai.FileReaderRequest{
+		Name:     "data.jsonl",
+		Reader:   strings.NewReader(content),
+		Purpose:  openai.PurposeBatch,
+		Progress: progress,
+	})
+	checks.NoError(t, err, "CreateFileReader error")
+	if file.Bytes != len(content) || file.FileName != "data.jsonl" {
+		t.Errorf("unexpected file: %+v", file)
+	}
+	if contentLength <= int64(len(content)) || sent != contentLength || total != contentLength {
+		t.Errorf("content length %d, progress %d of %d", contentLength, sent, total)
+	}
+
+	// The size of an arbitrary reader is unknown, the file is sent chunked.
+	file, err = client.CreateFileReader(context.Background(), openai.FileReaderRequest{
+		Name:     "data.jsonl",
+		Reader:   io.MultiReader(strings.NewReader(content)),
+		Purpose:  openai.PurposeBatch,
+		Progress: progress,
+	})
+	checks.NoError(t, err, "CreateFileReader error")
+	if file.Bytes != len(content) || contentLength != -1 || total != -1 || sent <= int64(len(content)) {
+		t.Errorf("unexpected chunked upload: file %+v, content length %d, progress %d of %d",
+			file, contentLength, sent, total)
+	}
+}
+
+func TestFileReaderUploadReadError(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/files", handleCreateFile)
+
+	readErr := errors.New("read failed")
+	_, err := client.CreateFileReader(context.Background(), openai.FileReaderRequest{
+		Name:    "data.jsonl",
+		Reader:  io.MultiReader(strings.NewReader("line\n"), iotest.ErrReader(readErr)),
+		Purpose: openai.PurposeBatch,
+	})
+	checks.ErrorIs(t, err, readErr, "CreateFileReader did not return the read error")
+}
+
 // handleCreateFile Handles the images endpoint by the test server.
 func handleCreateFile(w http.ResponseWriter, r *http.Request) {
 	var err error
@@ -2,51 +2,178 @@ package openai
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
 )
 
 type FineTuningJob struct {
-	ID              string          `json:"id"`
-	Object          string          `json:"object"`
-	CreatedAt       int64           `json:"created_at"`
-	FinishedAt      int64           `json:"finished_at"`
-	Model           string          `json:"model"`
-	FineTunedModel  string          `json:"fine_tuned_model,omitempty"`
-	OrganizationID  string          `json:"organization_id"`
-	Status          string          `json:"status"`
-	Hyperparameters Hyperparameters `json:"hyperparameters"`
-	TrainingFile    string          `json:"training_file"`
-	ValidationFile  string          `json:"validation_file,omitempty"`
-	ResultFiles     []string        `json:"result_files"`
-	TrainedTokens   int             `json:"trained_tokens"`
+	ID              string                  `json:"id"`
+	Object          string                  `json:"object"`
+	CreatedAt       int64                   `json:"created_at"`
+	FinishedAt      int64                   `json:"finished_at"`
+	Model           string                  `json:"model"`
+	FineTunedModel  string                  `json:"fine_tuned_model,omitempty"`
+	OrganizationID  string                  `json:"organization_id"`
+	Status          string                  `json:"status"`
+	Hyperparameters Hyperparameters         `json:"hyperparameters"`
+	TrainingFile    string                  `json:"training_file"`
+	ValidationFile  string                  `json:"validation_file,omitempty"`
+	ResultFiles     []string                `json:"result_files"`
+	TrainedTokens   int                     `json:"trained_tokens"`
+	Seed            int                     `json:"seed"`
+	Integrations    []FineTuningIntegration `json:"integrations,omitempty"`
+	Method          *FineTuningMethod       `json:"method,omitempty"`
+	EstimatedFinish int64                   `json:"estimated_finish,omitempty"`
+	Error           *FineTuningJobError     `json:"error,omitempty"`
 
 	httpHeader
 }
 
+// FineTuningJobError is the reason a fine tuning job failed.
+type FineTuningJobError struct {
+	Code    string  `json:"code"`
+	Message string  `json:"message"`
+	Param   *string `json:"param"`
+}
+
+// HyperparameterValue is a hyperparameter that is either "auto", letting the API pick it, or a number.
+type HyperparameterValue struct {
+	Auto   bool
+	Number float64
+}
+
+// AutoHyperparameter returns a hyperparameter picked by the API.
+func AutoHyperparameter() *HyperparameterValue {
+	return &HyperparameterValue{Auto: true}
+}
+
+// NumberHyperparameter returns a hyperparameter set to a number.
+func NumberHyperparameter(number float64) *HyperparameterValue {
+	return &HyperparameterValue{Number: number}
+}
+
+func (v HyperparameterValue) MarshalJSON() ([]byte, error) {
+	if v.Auto {
+		return []byte(`"auto"`), nil
+	}
+	return json.Marshal(v.Number)
+}
+
+func (v *HyperparameterValue) UnmarshalJSON(data []byte) error {
+	var auto string
+	if err := json.Unmarshal(data, &auto); err == nil {
+		if auto != "auto" {
+			return fmt.Errorf("invalid hyperparameter %q", auto)
+		}
+		*v = HyperparameterValue{Auto: true}
+		return nil
+	}
+	*v = HyperparameterValue{}
+	return json.Unmarshal(data, &v.Number)
+}
+
+// Hyperparameters of a fine tuning job. Unset hyperparameters are picked by the API.
 type Hyperparameters struct {
-	Epochs                 any `json:"n_epochs,omitempty"`
-	LearningRateMultiplier any `json:"learning_rate_multiplier,omitempty"`
-	BatchSize              any `json:"batch_size,omitempty"`
+	Epochs                 *HyperparameterValue `json:"n_epochs,omitempty"`
+	LearningRateMultiplier *HyperparameterValue `json:"learning_rate_multiplier,omitempty"`
+	BatchSize              *HyperparameterValue `json:"batch_size,omitempty"`
+	// Beta weighs the penalty between the policy and reference models, for the DPO method only.
+	Beta *HyperparameterValue `json:"beta,omitempty"`
 }
 
-type FineTuningJobRequest struct {
-	TrainingFile    string           `json:"training_file"`
-	ValidationFile  string           `json:"validation_file,omitempty"`
-	Model           string           `json:"model,omitempty"`
+type FineTuningMethodType string
+
+const (
+	FineTuningMethodSupervised FineTuningMethodType = "supervised"
+	FineTuningMethodDPO        FineTuningMethodType = "dpo"
+)
+
+// FineTuningMethod selects supervised fine-tuning or direct preference optimization, along with
+// the hyperparameters of the method.
+type FineTuningMethod struct {
+	Type       FineTuningMethodType        `json:"type"`
+	Supervised *FineTuningMethodParameters `json:"supervised,omitempty"`
+	DPO        *FineTuningMethodParameters `json:"dpo,omitempty"`
+}
+
+type FineTuningMethodParameters struct {
 	Hyperparameters *Hyperparameters `json:"hyperparameters,omitempty"`
-	Suffix          string           `json:"suffix,omitempty"`
 }
 
-type FineTuningJobEventList struct {
+// FineTuningIntegration reports the progress of a fine tuning job to a third party service.
+type FineTuningIntegration struct {
+	Type  string                      `json:"type"`
+	Wandb *FineTuningWandbIntegration `json:"wandb,omitempty"`
+}
+
+// FineTuningWandbIntegration reports a fine tuning job to a Weights and Biases project.
+type FineTuningWandbIntegration struct {
+	Project string   `json:"project"`
+	Name    string   `json:"name,omitempty"`
+	Entity  string   `json:"entity,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+}
+
+type FineTuningJobRequest struct {
+	TrainingFile   string `json:"training_file"`
+	ValidationFile string `json:"validation_file,omitempty"`
+	Model          string `json:"model,omitempty"`
+	// Hyperparameters are deprecated in favor of the hyperparameters of the method.
+	Hyperparameters *Hyperparameters        `json:"hyperparameters,omitempty"`
+	Suffix          string                  `json:"suffix,omitempty"`
+	Seed            *int                    `json:"seed,omitempty"`
+	Integrations    []FineTuningIntegration `json:"integrations,omitempty"`
+	Method          *FineTuningMethod       `json:"method,omitempty"`
+}
+
+type FineTuningJobList struct {
 	Object  string          `json:"object"`
-	Data    []FineTuneEvent `json:"data"`
+	Data    []FineTuningJob `json:"data"`
 	HasMore bool            `json:"has_more"`
 
 	httpHeader
 }
 
+// FineTuningJobCheckpoint is a model saved at the end of an epoch of a fine tuning job.
+type FineTuningJobCheckpoint struct {
+	ID                       string            `json:"id"`
+	Object                   string            `json:"object"`
+	CreatedAt                int64             `json:"created_at"`
+	FineTunedModelCheckpoint string            `json:"fine_tuned_model_checkpoint"`
+	FineTuningJobID          string            `json:"fine_tuning_job_id"`
+	StepNumber               int               `json:"step_number"`
+	Metrics                  FineTuningMetrics `json:"metrics"`
+}
+
+type FineTuningJobCheckpointList struct {
+	Object  string                    `json:"object"`
+	Data    []FineTuningJobCheckpoint `json:"data"`
+	FirstID string                    `json:"first_id"`
+	LastID  string                    `json:"last_id"`
+	HasMore bool                      `json:"has_more"`
+
+	httpHeader
+}
+
+const (
+	FineTuningJobStatusValidatingFiles = "validating_files"
+	FineTuningJobStatusQueued          = "queued"
+	FineTuningJobStatusRunning         = "running"
+	FineTuningJobStatusSucceeded       = "succeeded"
+	FineTuningJobStatusFailed          = "failed"
+	FineTuningJobStatusCancelled       = "cancelled"
+)
+
+type FineTuningJobEventList struct {
+	Object  string               `json:"object"`
+	Data    []FineTuningJobEvent `json:"data"`
+	HasMore bool                 `json:"has_more"`
+
+	httpHeader
+}
+
 type FineTuningJobEvent struct {
 	Object    string `json:"object"`
 	ID        string `json:"id"`
@@ -98,6 +225,80 @@ func (c *Client) RetrieveFineTuningJob(
 	return
 }
 
+// ListFineTuningJobs lists the fine tuning jobs of the organization, newest first.
+func (c *Client) ListFineTuningJobs(
+	ctx context.Context,
+	after *string,
+	limit *int,
+) (response FineTuningJobList, err error) {
+	urlSuffix := "/fine_tuning/jobs" + encodeListValues(after, limit)
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// ListFineTuningJobsPager returns a Pager over all fine tuning jobs. Only Limit and After of the pagination are used.
+func (c *Client) ListFineTuningJobsPager(ctx context.Context, pagination Pagination) *Pager[FineTuningJob] {
+	return newPager(ctx, pagination, func(ctx context.Context, page Pagination) ([]FineTuningJob, bool, string, error) {
+		list, err := c.ListFineTuningJobs(ctx, page.After, page.Limit)
+		lastID := ""
+		if len(list.Data) > 0 {
+			lastID = list.Data[len(list.Data)-1].ID
+		}
+		return list.Data, list.HasMore, lastID, err
+	})
+}
+
+// ListFineTuningJobCheckpoints lists the checkpoints of a fine tuning job, newest first.
+func (c *Client) ListFineTuningJobCheckpoints(
+	ctx context.Context,
+	fineTuningJobID string,
+	after *string,
+	limit *int,
+) (response FineTuningJobCheckpointList, err error) {
+	urlSuffix := fmt.Sprintf("/fine_tuning/jobs/%s/checkpoints%s", fineTuningJobID, encodeListValues(after, limit))
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// ListFineTuningJobCheckpointsPager returns a Pager over all checkpoints of a fine tuning job.
+// Only Limit and After of the pagination are used.
+func (c *Client) ListFineTuningJobCheckpointsPager(
+	ctx context.Context,
+	fineTuningJobID string,
+	pagination Pagination,
+) *Pager[FineTuningJobCheckpoint] {
+	fetch := func(ctx context.Context, page Pagination) ([]FineTuningJobCheckpoint, bool, string, error) {
+		list, err := c.ListFineTuningJobCheckpoints(ctx, fineTuningJobID, page.After, page.Limit)
+		return list.Data, list.HasMore, list.LastID, err
+	}
+	return newPager(ctx, pagination, fetch)
+}
+
+// encodeListValues returns the query of a list request paginated with after and limit.
+func encodeListValues(after *string, limit *int) string {
+	urlValues := url.Values{}
+	if after != nil {
+		urlValues.Add("after", *after)
+	}
+	if limit != nil {
+		urlValues.Add("limit", fmt.Sprintf("%d", *limit))
+	}
+	if len(urlValues) == 0 {
+		return ""
+	}
+	return "?" + urlValues.Encode()
+}
+
 type listFineTuningJobEventsParameters struct {
 	after *string
 	limit *int
@@ -157,3 +358,33 @@ func (c *Client) ListFineTuningJobEvents(
 	err = c.sendRequest(req, &response)
 	return
 }
+
+// ListFineTuningJobEventsPager returns a Pager over all events of a fine tuning job.
+// Only Limit and After of the pagination are used.
+func (c *Client) ListFineTuningJobEventsPager(
+	ctx context.Context,
+	fineTuningJobID string,
+	pagination Pagination,
+) *Pager[FineTuningJobEvent] {
+	fetch := func(ctx context.Context, page Pagination) (
+		events []FineTuningJobEvent, hasMore bool, lastID string, err error) {
+		var setters []ListFineTuningJobEventsParameter
+		if page.After != nil {
+			setters = append(setters, ListFineTuningJobEventsWithAfter(*page.After))
+		}
+		if page.Limit != nil {
+			setters = append(setters, ListFineTuningJobEventsWithLimit(*page.Limit))
+		}
+
+		response, err := c.ListFineTuningJobEvents(ctx, fineTuningJobID, setters...)
+		if err != nil {
+			return
+		}
+		events, hasMore = response.Data, response.HasMore
+		if len(events) > 0 {
+			lastID = events[len(events)-1].ID
+		}
+		return
+	}
+	return newPager(ctx, pagination, fetch)
+}
@@ -33,9 +33,9 @@ func TestFineTuningJob(t *testing.T) {
 				ValidationFile: "",
 				TrainingFile:   "file-abc123",
 				Hyperparameters: openai.Hyperparameters{
-					Epochs:                 "auto",
-					LearningRateMultiplier: "auto",
-					BatchSize:              "auto",
+					Epochs:                 openai.AutoHyperparameter(),
+					LearningRateMultiplier: openai.AutoHyperparameter(),
+					BatchSize:              openai.AutoHyperparameter(),
 				},
 				TrainedTokens: 5768,
 			})
@@ -104,3 +104,86 @@ func TestFineTuningJob(t *testing.T) {
 	)
 	checks.NoError(t, err, "ListFineTuningJobEvents error")
 }
+
+func TestListFineTuningJobs(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/fine_tuning/jobs", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("after") == "" {
+			fmt.Fprint(w, `{"object":"list","data":[{"id":"ftjob_2","seed":42,"hyperparameters":{"n_epochs":"auto"},`+
+				`"method":{"type":"dpo","dpo":{"hyperparameters":{"beta":0.1}}}}],"has_more":true}`)
+			return
+		}
+		fmt.Fprint(w, `{"object":"list","data":[{"id":"ftjob_1"}],"has_more":false}`)
+	})
+
+	limit := 1
+	jobs, err := client.ListFineTuningJobs(context.Background(), nil, &limit)
+	checks.NoError(t, err, "ListFineTuningJobs error")
+	job := jobs.Data[0]
+	if job.Seed != 42 || !job.Hyperparameters.Epochs.Auto || job.Method.Type != openai.FineTuningMethodDPO ||
+		job.Method.DPO.Hyperparameters.Beta.Number != 0.1 {
+		t.Errorf("unexpected job: %+v", job)
+	}
+
+	var ids []string
+	pager := client.ListFineTuningJobsPager(context.Background(), openai.Pagination{Limit: &limit})
+	for pager.Next() {
+		ids = append(ids, pager.Current().ID)
+	}
+	checks.NoError(t, pager.Err(), "Pager error")
+	if fmt.Sprint(ids) != "[ftjob_2 ftjob_1]" {
+		t.Errorf("unexpected jobs: %v", ids)
+	}
+}
+
+func TestListFineTuningJobCheckpoints(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/fine_tuning/jobs/ftjob_1/checkpoints", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("limit") != "5" {
+			t.Errorf("unexpected query %q", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"object":"list","data":[{"id":"ftckpt_1","step_number":88,`+
+			`"fine_tuned_model_checkpoint":"ft:gpt-4o-mini:org::ckpt-step-88",`+
+			`"metrics":{"step":88,"train_loss":0.1,"valid_loss":0.2}}],"last_id":"ftckpt_1","has_more":false}`)
+	})
+
+	limit := 5
+	checkpoints, err := client.ListFineTuningJobCheckpoints(context.Background(), "ftjob_1", nil, &limit)
+	checks.NoError(t, err, "ListFineTuningJobCheckpoints error")
+	checkpoint := checkpoints.Data[0]
+	if checkpoint.StepNumber != 88 || checkpoint.Metrics.TrainLoss != 0.1 || *checkpoint.Metrics.ValidLoss != 0.2 {
+		t.Errorf("unexpected checkpoint: %+v", checkpoint)
+	}
+}
+
+func TestHyperparameterValue(t *testing.T) {
+	seed := 7
+	request := openai.FineTuningJobRequest{
+		TrainingFile: "file-1",
+		Seed:         &seed,
+		Method: &openai.FineTuningMethod{
+			Type: openai.FineTuningMethodSupervised,
+			Supervised: &openai.FineTuningMethodParameters{Hyperparameters: &openai.Hyperparameters{
+				Epochs:                 openai.NumberHyperparameter(3),
+				LearningRateMultiplier: openai.NumberHyperparameter(0.5),
+				BatchSize:              openai.AutoHyperparameter(),
+			}},
+		},
+		Integrations: []openai.FineTuningIntegration{
+			{Type: "wandb", Wandb: &openai.FineTuningWandbIntegration{Project: "tuning"}},
+		},
+	}
+	data, err := json.Marshal(request)
+	checks.NoError(t, err, "Marshal error")
+	want := `{"training_file":"file-1","seed":7,"integrations":[{"type":"wandb","wandb":{"project":"tuning"}}],` +
+		`"method":{"type":"supervised","supervised":{"hyperparameters":` +
+		`{"n_epochs":3,"learning_rate_multiplier":0.5,"batch_size":"auto"}}}}`
+	if string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+
+	var value openai.HyperparameterValue
+	checks.HasError(t, json.Unmarshal([]byte(`"manual"`), &value), "only auto is a valid string hyperparameter")
+}
@@ -0,0 +1,194 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+const (
+	defaultFineTuningPollInterval    = 5 * time.Second
+	defaultFineTuningMaxPollInterval = time.Minute
+	defaultFineTuningPollMultiplier  = 1.5
+	fineTuningEventsPageSize         = 100
+)
+
+// FineTuningMetrics are the metrics of a training step. Validation metrics are nil for the steps
+// they were not computed at.
+type FineTuningMetrics struct {
+	Step       int `json:"step"`
+	TotalSteps int `json:"total_steps,omitempty"`
+
+	TrainLoss              float64 `json:"train_loss"`
+	TrainMeanTokenAccuracy float64 `json:"train_mean_token_accuracy"`
+
+	ValidLoss              *float64 `json:"valid_loss,omitempty"`
+	ValidMeanTokenAccuracy *float64 `json:"valid_mean_token_accuracy,omitempty"`
+	// The full validation metrics are computed over the whole validation file at the end of every epoch.
+	FullValidLoss              *float64 `json:"full_valid_loss,omitempty"`
+	FullValidMeanTokenAccuracy *float64 `json:"full_valid_mean_token_accuracy,omitempty"`
+}
+
+// Metrics returns the step metrics of an event of type "metrics".
+func (e FineTuningJobEvent) Metrics() (metrics FineTuningMetrics, ok bool) {
+	if e.Type != "metrics" || e.Data == nil {
+		return
+	}
+	data, err := json.Marshal(e.Data)
+	if err != nil {
+		return
+	}
+	if err = json.Unmarshal(data, &metrics); err != nil {
+		return
+	}
+	return metrics, true
+}
+
+// FineTuningJobWatcher streams the events of a fine tuning job in chronological order, polling the job
+// until it succeeds, fails or is cancelled.
+type FineTuningJobWatcher struct {
+	// PollInterval is the delay between polls. It grows by PollMultiplier up to MaxPollInterval while
+	// the job reports no new events. The fields may be changed before the first call to Recv.
+	// A PollInterval of zero or less and a PollMultiplier under one use the defaults.
+	PollInterval    time.Duration
+	MaxPollInterval time.Duration
+	PollMultiplier  float64
+
+	ctx    context.Context
+	client *Client
+	jobID  string
+
+	job         FineTuningJob
+	events      []FineTuningJobEvent
+	lastEventID string
+	interval    time.Duration
+	polled      bool
+	done        bool
+}
+
+// WatchFineTuningJob returns a watcher over the events of a fine tuning job, with default polling settings.
+func (c *Client) WatchFineTuningJob(ctx context.Context, fineTuningJobID string) *FineTuningJobWatcher {
+	return &FineTuningJobWatcher{
+		PollInterval:    defaultFineTuningPollInterval,
+		MaxPollInterval: defaultFineTuningMaxPollInterval,
+		PollMultiplier:  defaultFineTuningPollMultiplier,
+		ctx:             ctx,
+		client:          c,
+		jobID:           fineTuningJobID,
+	}
+}
+
+// Recv returns the next event of the job, waiting for it if needed. It returns io.EOF once the job
+// reached a terminal status and all of its events were returned.
+func (w *FineTuningJobWatcher) Recv() (event FineTuningJobEvent, err error) {
+	for len(w.events) == 0 {
+		if w.done {
+			return event, io.EOF
+		}
+		if err = w.poll(); err != nil {
+			return
+		}
+	}
+
+	event = w.events[0]
+	w.events = w.events[1:]
+	return
+}
+
+// Job returns the job as of the last poll.
+func (w *FineTuningJobWatcher) Job() FineTuningJob {
+	return w.job
+}
+
+// poll waits for the poll interval, except on the first poll, then retrieves the job and its new events.
+func (w *FineTuningJobWatcher) poll() error {
+	initial := w.PollInterval
+	if initial <= 0 {
+		initial = defaultFineTuningPollInterval
+	}
+	multiplier := w.PollMultiplier
+	if multiplier < 1 {
+		multiplier = defaultFineTuningPollMultiplier
+	}
+
+	if w.polled {
+		timer := time.NewTimer(w.interval)
+		select {
+		case <-w.ctx.Done():
+			timer.Stop()
+			return w.ctx.Err()
+		case <-timer.C:
+		}
+		w.interval = time.Duration(float64(w.interval) * multiplier)
+		if w.MaxPollInterval > 0 && w.interval > w.MaxPollInterval {
+			w.interval = w.MaxPollInterval
+		}
+	} else {
+		w.interval = initial
+	}
+	w.polled = true
+
+	// The job is retrieved before the events, so that the events of a finished job are complete.
+	job, err := w.client.RetrieveFineTuningJob(w.ctx, w.jobID)
+	if err != nil {
+		return err
+	}
+	w.job = job
+	w.done = isTerminalFineTuningJobStatus(job.Status)
+
+	events, err := w.newEvents()
+	if err != nil {
+		return err
+	}
+	w.events = events
+	if len(events) > 0 {
+		w.interval = initial
+	}
+	return nil
+}
+
+// newEvents lists the events since the last one returned, oldest first. The API lists events newest
+// first, so pages are followed until the last returned event is found.
+func (w *FineTuningJobWatcher) newEvents() ([]FineTuningJobEvent, error) {
+	var events []FineTuningJobEvent
+	setters := []ListFineTuningJobEventsParameter{ListFineTuningJobEventsWithLimit(fineTuningEventsPageSize)}
+	for {
+		list, err := w.client.ListFineTuningJobEvents(w.ctx, w.jobID, setters...)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, event := range list.Data {
+			if event.ID == w.lastEventID {
+				list.HasMore = false
+				break
+			}
+			events = append(events, event)
+		}
+		if !list.HasMore || len(list.Data) == 0 {
+			break
+		}
+		setters = []ListFineTuningJobEventsParameter{
+			ListFineTuningJobEventsWithLimit(fineTuningEventsPageSize),
+			ListFineTuningJobEventsWithAfter(list.Data[len(list.Data)-1].ID),
+		}
+	}
+
+	for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+		events[i], events[j] = events[j], events[i]
+	}
+	if len(events) > 0 {
+		w.lastEventID = events[len(events)-1].ID
+	}
+	return events, nil
+}
+
+func isTerminalFineTuningJobStatus(status string) bool {
+	switch status {
+	case FineTuningJobStatusSucceeded, FineTuningJobStatusFailed, FineTuningJobStatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,141 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/neospace-ai/go-openai"
+	"github.com/neospace-ai/go-openai/internal/test/checks"
+)
+
+func TestFineTuningJobWatcher(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var polls atomic.Int32
+	// The job has 2 more events at every poll, and succeeds at the third one with a fifth event.
+	visibleEvents := []int{0, 2, 4, 5}
+	server.RegisterHandler("/v1/fine_tuning/jobs/ftjob_1*", func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/events") {
+			status := openai.FineTuningJobStatusRunning
+			if polls.Add(1) == 3 {
+				status = openai.FineTuningJobStatusSucceeded
+			}
+			fmt.Fprintf(w, `{"id":"ftjob_1","status":%q}`, status)
+			return
+		}
+
+		// Newest first, two events per page.
+		var page []openai.FineTuningJobEvent
+		for i := visibleEvents[polls.Load()]; i >= 1; i-- {
+			page = append(page, openai.FineTuningJobEvent{ID: fmt.Sprintf("ftevent_%d", i), Type: "metrics",
+				Data: map[string]any{"step": i, "train_loss": 1.0 / float64(i)}})
+		}
+		if after := r.URL.Query().Get("after"); after != "" {
+			for i, event := range page {
+				if event.ID == after {
+					page = page[i+1:]
+					break
+				}
+			}
+		}
+		list := openai.FineTuningJobEventList{Data: page, HasMore: len(page) > 2}
+		if list.HasMore {
+			list.Data = page[:2]
+		}
+		checks.NoError(t, json.NewEncoder(w).Encode(list), "Encode error")
+	})
+
+	watcher := client.WatchFineTuningJob(context.Background(), "ftjob_1")
+	watcher.PollInterval = time.Millisecond
+	var steps []int
+	for {
+		event, err := watcher.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		checks.NoError(t, err, "Recv error")
+		metrics, ok := event.Metrics()
+		if !ok || metrics.TrainLoss != 1.0/float64(metrics.Step) {
+			t.Fatalf("unexpected metrics %+v for event %+v", metrics, event)
+		}
+		steps = append(steps, metrics.Step)
+	}
+
+	if fmt.Sprint(steps) != "[1 2 3 4 5]" {
+		t.Errorf("events were not streamed in order: %v", steps)
+	}
+	if watcher.Job().Status != openai.FineTuningJobStatusSucceeded || polls.Load() != 3 {
+		t.Errorf("watcher did not stop at the terminal status: %+v after %d polls", watcher.Job(), polls.Load())
+	}
+}
+
+func TestFineTuningJobWatcherCancelled(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/fine_tuning/jobs/ftjob_1*", func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/events") {
+			fmt.Fprint(w, `{"data":[],"has_more":false}`)
+			return
+		}
+		fmt.Fprint(w, `{"id":"ftjob_1","status":"running"}`)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	watcher := client.WatchFineTuningJob(ctx, "ftjob_1")
+	watcher.PollInterval = time.Millisecond
+	_, err := watcher.Recv()
+	checks.ErrorIs(t, err, context.DeadlineExceeded, "Recv did not return the context error")
+}
+
+func TestFineTuningJobWatcherDefaultsPollSettings(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	var polls atomic.Int32
+	server.RegisterHandler("/v1/fine_tuning/jobs/ftjob_1*", func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/events") {
+			fmt.Fprint(w, `{"data":[],"has_more":false}`)
+			return
+		}
+		polls.Add(1)
+		fmt.Fprint(w, `{"id":"ftjob_1","status":"running"}`)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	watcher := client.WatchFineTuningJob(ctx, "ftjob_1")
+	watcher.PollInterval = 0
+	watcher.PollMultiplier = 0
+	_, err := watcher.Recv()
+	checks.ErrorIs(t, err, context.DeadlineExceeded, "Recv did not return the context error")
+	// Only the first poll is immediate, the next one waits for the default interval.
+	if polls.Load() != 1 {
+		t.Errorf("expected a single poll, got %d", polls.Load())
+	}
+}
+
+func TestFineTuningJobEventMetrics(t *testing.T) {
+	var event openai.FineTuningJobEvent
+	checks.NoError(t, json.Unmarshal([]byte(`{"id":"ftevent_1","type":"metrics","data":{"step":7,"total_steps":10,`+
+		`"train_loss":0.5,"train_mean_token_accuracy":0.8,"valid_loss":0.6}}`), &event), "Unmarshal error")
+
+	metrics, ok := event.Metrics()
+	if !ok || metrics.Step != 7 || metrics.TotalSteps != 10 || metrics.TrainMeanTokenAccuracy != 0.8 ||
+		metrics.ValidLoss == nil || *metrics.ValidLoss != 0.6 || metrics.ValidMeanTokenAccuracy != nil {
+		t.Errorf("unexpected metrics: %+v", metrics)
+	}
+
+	event.Type = "message"
+	if _, ok = event.Metrics(); ok {
+		t.Error("message events have no metrics")
+	}
+}
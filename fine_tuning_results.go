@@ -0,0 +1,115 @@
+package openai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var ErrFineTuningNoResultFiles = errors.New("fine tuning job has no result files")
+
+// ParseFineTuningResultFile parses the CSV result file of a fine tuning job into the metrics of
+// every step. Columns are matched by name, so files with more or fewer columns are supported, and
+// empty cells leave the optional metrics nil. Result files downloaded base64-encoded, as the API
+// serves them, are decoded transparently.
+func ParseFineTuningResultFile(r io.Reader) ([]FineTuningMetrics, error) {
+	buffered := bufio.NewReader(r)
+	header, err := buffered.Peek(len("step"))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if !bytes.Equal(header, []byte("step")) {
+		r = base64.NewDecoder(base64.StdEncoding, buffered)
+	} else {
+		r = buffered
+	}
+
+	reader := csv.NewReader(r)
+	columns, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var metrics []FineTuningMetrics
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return metrics, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var step FineTuningMetrics
+		for i, column := range columns {
+			if err = step.setColumn(strings.TrimSpace(column), strings.TrimSpace(record[i])); err != nil {
+				line, _ := reader.FieldPos(i)
+				return nil, fmt.Errorf("line %d: column %s: %w", line, column, err)
+			}
+		}
+		metrics = append(metrics, step)
+	}
+}
+
+func (m *FineTuningMetrics) setColumn(column, value string) (err error) {
+	if value == "" {
+		return nil
+	}
+	switch column {
+	case "step":
+		m.Step, err = strconv.Atoi(value)
+	case "train_loss":
+		m.TrainLoss, err = strconv.ParseFloat(value, 64)
+	case "train_accuracy", "train_mean_token_accuracy":
+		m.TrainMeanTokenAccuracy, err = strconv.ParseFloat(value, 64)
+	case "valid_loss":
+		m.ValidLoss, err = parseOptionalFloat(value)
+	case "valid_accuracy", "valid_mean_token_accuracy":
+		m.ValidMeanTokenAccuracy, err = parseOptionalFloat(value)
+	case "full_valid_loss":
+		m.FullValidLoss, err = parseOptionalFloat(value)
+	case "full_valid_accuracy", "full_valid_mean_token_accuracy":
+		m.FullValidMeanTokenAccuracy, err = parseOptionalFloat(value)
+	}
+	return
+}
+
+func parseOptionalFloat(value string) (*float64, error) {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// GetFineTuningJobMetrics downloads and parses the result files of a finished fine tuning job.
+func (c *Client) GetFineTuningJobMetrics(ctx context.Context, job FineTuningJob) ([]FineTuningMetrics, error) {
+	if len(job.ResultFiles) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrFineTuningNoResultFiles, job.ID)
+	}
+
+	var metrics []FineTuningMetrics
+	for _, fileID := range job.ResultFiles {
+		content, err := c.GetFileContent(ctx, fileID)
+		if err != nil {
+			return nil, err
+		}
+		fileMetrics, err := ParseFineTuningResultFile(content)
+		content.Close()
+		if err != nil {
+			return nil, fmt.Errorf("result file %s: %w", fileID, err)
+		}
+		metrics = append(metrics, fileMetrics...)
+	}
+	return metrics, nil
+}
@@ -0,0 +1,63 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/neospace-ai/go-openai"
+	"github.com/neospace-ai/go-openai/internal/test/checks"
+)
+
+const fineTuningResultFile = `step,train_loss,train_accuracy,valid_loss,valid_mean_token_accuracy
+1,1.5,0.5,,
+2,1.25,0.6,1.4,0.55
+`
+
+func TestParseFineTuningResultFile(t *testing.T) {
+	for name, content := range map[string]string{
+		"csv":    fineTuningResultFile,
+		"base64": base64.StdEncoding.EncodeToString([]byte(fineTuningResultFile)),
+	} {
+		t.Run(name, func(t *testing.T) {
+			metrics, err := openai.ParseFineTuningResultFile(strings.NewReader(content))
+			checks.NoError(t, err, "ParseFineTuningResultFile error")
+			if len(metrics) != 2 {
+				t.Fatalf("expected 2 steps, got %d", len(metrics))
+			}
+			if metrics[0].Step != 1 || metrics[0].TrainLoss != 1.5 || metrics[0].ValidLoss != nil {
+				t.Errorf("unexpected first step: %+v", metrics[0])
+			}
+			if metrics[1].TrainMeanTokenAccuracy != 0.6 || metrics[1].ValidLoss == nil || *metrics[1].ValidLoss != 1.4 ||
+				metrics[1].ValidMeanTokenAccuracy == nil || *metrics[1].ValidMeanTokenAccuracy != 0.55 {
+				t.Errorf("unexpected second step: %+v", metrics[1])
+			}
+		})
+	}
+
+	_, err := openai.ParseFineTuningResultFile(strings.NewReader("step,train_loss\n1,abc\n"))
+	checks.HasError(t, err, "ParseFineTuningResultFile should fail on invalid numbers")
+}
+
+func TestGetFineTuningJobMetrics(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/files/file-result/content", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, base64.StdEncoding.EncodeToString([]byte(fineTuningResultFile)))
+	})
+
+	metrics, err := client.GetFineTuningJobMetrics(context.Background(), openai.FineTuningJob{
+		ID:          "ftjob_1",
+		ResultFiles: []string{"file-result"},
+	})
+	checks.NoError(t, err, "GetFineTuningJobMetrics error")
+	if len(metrics) != 2 || metrics[1].Step != 2 {
+		t.Errorf("unexpected metrics: %+v", metrics)
+	}
+
+	_, err = client.GetFineTuningJobMetrics(context.Background(), openai.FineTuningJob{ID: "ftjob_1"})
+	checks.ErrorIs(t, err, openai.ErrFineTuningNoResultFiles, "GetFineTuningJobMetrics without result files")
+}
@@ -1,11 +1,12 @@
 package openai
 
 import (
-	"bytes"
 	"context"
 	"net/http"
 	"os"
 	"strconv"
+
+	utils "github.com/neospace-ai/go-openai/internal"
 )
 
 // Image sizes defined by the OpenAI API.
@@ -90,55 +91,44 @@ type ImageEditRequest struct {
 
 // CreateEditImage - API call to create an image. This is the main endpoint of the DALL-E API.
 func (c *Client) CreateEditImage(ctx context.Context, request ImageEditRequest) (response ImageResponse, err error) {
-	body := &bytes.Buffer{}
-	builder := c.createFormBuilder(body)
-
-	// image
-	err = builder.CreateFormFile("image", request.Image)
-	if err != nil {
-		return
-	}
-
-	// mask, it is optional
-	if request.Mask != nil {
-		err = builder.CreateFormFile("mask", request.Mask)
+	url := c.fullURL("/images/edits", request.Model)
+	err = c.sendMultipartRequest(ctx, url, nil, func(builder utils.FormBuilder) error {
+		// image
+		err := builder.CreateFormFile("image", request.Image)
 		if err != nil {
-			return
+			return err
 		}
-	}
 
-	err = builder.WriteField("prompt", request.Prompt)
-	if err != nil {
-		return
-	}
-
-	err = builder.WriteField("n", strconv.Itoa(request.N))
-	if err != nil {
-		return
-	}
+		// mask, it is optional
+		if request.Mask != nil {
+			err = builder.CreateFormFile("mask", request.Mask)
+			if err != nil {
+				return err
+			}
+		}
 
-	err = builder.WriteField("size", request.Size)
-	if err != nil {
-		return
-	}
+		err = builder.WriteField("prompt", request.Prompt)
+		if err != nil {
+			return err
+		}
 
-	err = builder.WriteField("response_format", request.ResponseFormat)
-	if err != nil {
-		return
-	}
+		err = builder.WriteField("n", strconv.Itoa(request.N))
+		if err != nil {
+			return err
+		}
 
-	err = builder.Close()
-	if err != nil {
-		return
-	}
+		err = builder.WriteField("size", request.Size)
+		if err != nil {
+			return err
+		}
 
-	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL("/images/edits", request.Model),
-		withBody(body), withContentType(builder.FormDataContentType()))
-	if err != nil {
-		return
-	}
+		err = builder.WriteField("response_format", request.ResponseFormat)
+		if err != nil {
+			return err
+		}
 
-	err = c.sendRequest(req, &response)
+		return builder.Close()
+	}, &response)
 	return
 }
 
@@ -154,41 +144,30 @@ type ImageVariRequest struct {
 // CreateVariImage - API call to create an image variation. This is the main endpoint of the DALL-E API.
 // Use abbreviations(vari for variation) because ci-lint has a single-line length limit ...
 func (c *Client) CreateVariImage(ctx context.Context, request ImageVariRequest) (response ImageResponse, err error) {
-	body := &bytes.Buffer{}
-	builder := c.createFormBuilder(body)
-
-	// image
-	err = builder.CreateFormFile("image", request.Image)
-	if err != nil {
-		return
-	}
-
-	err = builder.WriteField("n", strconv.Itoa(request.N))
-	if err != nil {
-		return
-	}
-
-	err = builder.WriteField("size", request.Size)
-	if err != nil {
-		return
-	}
+	url := c.fullURL("/images/variations", request.Model)
+	err = c.sendMultipartRequest(ctx, url, nil, func(builder utils.FormBuilder) error {
+		// image
+		err := builder.CreateFormFile("image", request.Image)
+		if err != nil {
+			return err
+		}
 
-	err = builder.WriteField("response_format", request.ResponseFormat)
-	if err != nil {
-		return
-	}
+		err = builder.WriteField("n", strconv.Itoa(request.N))
+		if err != nil {
+			return err
+		}
 
-	err = builder.Close()
-	if err != nil {
-		return
-	}
+		err = builder.WriteField("size", request.Size)
+		if err != nil {
+			return err
+		}
 
-	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL("/images/variations", request.Model),
-		withBody(body), withContentType(builder.FormDataContentType()))
-	if err != nil {
-		return
-	}
+		err = builder.WriteField("response_format", request.ResponseFormat)
+		if err != nil {
+			return err
+		}
 
-	err = c.sendRequest(req, &response)
+		return builder.Close()
+	}, &response)
 	return
 }
@@ -63,3 +63,96 @@ func (fb *DefaultFormBuilder) Close() error {
 func (fb *DefaultFormBuilder) FormDataContentType() string {
 	return fb.writer.FormDataContentType()
 }
+
+func (fb *DefaultFormBuilder) Boundary() string {
+	return fb.writer.Boundary()
+}
+
+// FormSizer is a FormBuilder that computes the size of a form without reading its files.
+// A form built by a DefaultFormBuilder with the same boundary has that exact size.
+type FormSizer struct {
+	counter countingWriter
+	writer  *multipart.Writer
+	unknown bool
+}
+
+func NewFormSizer(boundary string) *FormSizer {
+	fs := &FormSizer{}
+	fs.writer = multipart.NewWriter(&fs.counter)
+	if err := fs.writer.SetBoundary(boundary); err != nil {
+		fs.unknown = true
+	}
+	return fs
+}
+
+func (fs *FormSizer) CreateFormFile(fieldname string, file *os.File) error {
+	return fs.createFormFile(fieldname, file, file.Name())
+}
+
+func (fs *FormSizer) CreateFormFileReader(fieldname string, r io.Reader, filename string) error {
+	return fs.createFormFile(fieldname, r, path.Base(filename))
+}
+
+func (fs *FormSizer) createFormFile(fieldname string, r io.Reader, filename string) error {
+	if filename == "" {
+		return fmt.Errorf("filename cannot be empty")
+	}
+
+	_, err := fs.writer.CreateFormFile(fieldname, filename)
+	if err != nil {
+		return err
+	}
+
+	size, ok := ReaderSize(r)
+	if !ok {
+		fs.unknown = true
+	}
+	fs.counter.n += size
+	return nil
+}
+
+func (fs *FormSizer) WriteField(fieldname, value string) error {
+	return fs.writer.WriteField(fieldname, value)
+}
+
+func (fs *FormSizer) Close() error {
+	return fs.writer.Close()
+}
+
+func (fs *FormSizer) FormDataContentType() string {
+	return fs.writer.FormDataContentType()
+}
+
+// Size returns the size of the form, and false if the size of one of its files is unknown.
+func (fs *FormSizer) Size() (int64, bool) {
+	return fs.counter.n, !fs.unknown
+}
+
+// ReaderSize returns the number of bytes left to read from r, for readers that know it.
+func ReaderSize(r io.Reader) (int64, bool) {
+	switch r := r.(type) {
+	case interface{ Len() int }:
+		return int64(r.Len()), true
+	case *os.File:
+		info, err := r.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return 0, false
+		}
+		offset, err := r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return 0, false
+		}
+		return info.Size() - offset, true
+	default:
+		return 0, false
+	}
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
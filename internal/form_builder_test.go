@@ -6,6 +6,7 @@ import (
 
 	"bytes"
 	"errors"
+	"io"
 	"os"
 	"testing"
 )
@@ -52,3 +53,44 @@ func TestFormBuilderWithClosedFile(t *testing.T) {
 	checks.HasError(t, err, "formbuilder should return error if file is closed")
 	checks.ErrorIs(t, err, os.ErrClosed, "formbuilder should return error if file is closed")
 }
+
+func TestFormSizer(t *testing.T) {
+	dir, cleanup := test.CreateTestDirectory(t)
+	defer cleanup()
+
+	file, err := os.CreateTemp(dir, "")
+	checks.NoError(t, err, "Error creating tmp file")
+	defer file.Close()
+	_, err = file.WriteString("file contents")
+	checks.NoError(t, err, "Error writing tmp file")
+	_, err = file.Seek(5, io.SeekStart)
+	checks.NoError(t, err, "Error seeking tmp file")
+
+	build := func(builder FormBuilder) {
+		checks.NoError(t, builder.WriteField("purpose", "fine-tune"), "WriteField error")
+		checks.NoError(t, builder.CreateFormFile("file", file), "CreateFormFile error")
+		checks.NoError(t, builder.CreateFormFileReader("other", bytes.NewReader([]byte("bytes")), "a/b.txt"),
+			"CreateFormFileReader error")
+		checks.NoError(t, builder.Close(), "Close error")
+	}
+
+	body := &bytes.Buffer{}
+	builder := NewFormBuilder(body)
+	sizer := NewFormSizer(builder.Boundary())
+	build(sizer)
+	build(builder)
+
+	size, known := sizer.Size()
+	if !known || size != int64(body.Len()) {
+		t.Errorf("FormSizer size = %d, %v, want %d", size, known, body.Len())
+	}
+	if sizer.FormDataContentType() != builder.FormDataContentType() {
+		t.Errorf("FormSizer content type = %s, want %s", sizer.FormDataContentType(), builder.FormDataContentType())
+	}
+
+	sizer = NewFormSizer(builder.Boundary())
+	checks.NoError(t, sizer.CreateFormFileReader("file", io.MultiReader(), "file"), "CreateFormFileReader error")
+	if _, known = sizer.Size(); known {
+		t.Error("FormSizer should not know the size of an arbitrary reader")
+	}
+}
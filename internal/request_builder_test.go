@@ -59,3 +59,16 @@ func TestRequestBuilderReturnsRequestWhenRequestOfArgsIsNil(t *testing.T) {
 		t.Errorf("Build() got = %v, want %v", got, want)
 	}
 }
+
+func TestRequestBuilderUsesMethod(t *testing.T) {
+	b := NewRequestBuilder()
+	for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodDelete} {
+		r, err := b.Build(context.Background(), method, "/foo", nil, nil)
+		if err != nil {
+			t.Fatalf("Build(%s) error: %v", method, err)
+		}
+		if r.Method != method {
+			t.Errorf("Build(%s) method = %s", method, r.Method)
+		}
+	}
+}
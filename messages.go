@@ -2,9 +2,12 @@ package openai
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
+	"unicode/utf8"
 )
 
 const (
@@ -23,9 +26,26 @@ type Message struct {
 	RunID       *string          `json:"run_id,omitempty"`
 	Metadata    map[string]any   `json:"metadata"`
 
+	Status            MessageStatus             `json:"status,omitempty"`
+	IncompleteDetails *MessageIncompleteDetails `json:"incomplete_details,omitempty"`
+	Attachments       []ThreadAttachment        `json:"attachments,omitempty"`
+
 	httpHeader
 }
 
+type MessageStatus string
+
+const (
+	MessageStatusInProgress MessageStatus = "in_progress"
+	MessageStatusIncomplete MessageStatus = "incomplete"
+	MessageStatusCompleted  MessageStatus = "completed"
+)
+
+// MessageIncompleteDetails explains why a message ended with status incomplete.
+type MessageIncompleteDetails struct {
+	Reason string `json:"reason"`
+}
+
 type MessagesList struct {
 	Messages []Message `json:"data"`
 
@@ -38,24 +58,167 @@ type MessagesList struct {
 }
 
 type MessageContent struct {
+	Type      string           `json:"type"`
+	Text      *MessageText     `json:"text,omitempty"`
+	ImageFile *ImageFile       `json:"image_file,omitempty"`
+	ImageURL  *MessageImageURL `json:"image_url,omitempty"`
+}
+type MessageText struct {
+	Value       string              `json:"value"`
+	Annotations []MessageAnnotation `json:"annotations"`
+}
+
+type MessageAnnotationType string
+
+const (
+	MessageAnnotationTypeFileCitation MessageAnnotationType = "file_citation"
+	MessageAnnotationTypeFilePath     MessageAnnotationType = "file_path"
+)
+
+// MessageAnnotation marks the span of a message text, between StartIndex and EndIndex,
+// that cites a file or points to a file generated by the code interpreter.
+// Text is the literal marker found in the message, such as "【4:0†source】".
+type MessageAnnotation struct {
+	// Index is set only in message deltas.
+	Index        *int                  `json:"index,omitempty"`
+	Type         MessageAnnotationType `json:"type"`
+	Text         string                `json:"text"`
+	StartIndex   int                   `json:"start_index"`
+	EndIndex     int                   `json:"end_index"`
+	FileCitation *FileCitation         `json:"file_citation,omitempty"`
+	FilePath     *FilePath             `json:"file_path,omitempty"`
+}
+
+// FileCitation is a citation of a file searched by the file_search tool.
+type FileCitation struct {
+	FileID string `json:"file_id"`
+	Quote  string `json:"quote,omitempty"`
+}
+
+// FilePath is a file generated by the code_interpreter tool.
+type FilePath struct {
+	FileID string `json:"file_id"`
+}
+
+// FileID returns the ID of the file the annotation refers to.
+func (a MessageAnnotation) FileID() string {
+	switch {
+	case a.FileCitation != nil:
+		return a.FileCitation.FileID
+	case a.FilePath != nil:
+		return a.FilePath.FileID
+	default:
+		return ""
+	}
+}
+
+// ResolveAnnotations returns the text with every annotation replaced by the result of replace.
+// Annotations are located by their offsets, which count characters, and by their text
+// when the offsets do not match.
+func (t MessageText) ResolveAnnotations(replace func(i int, annotation MessageAnnotation) string) string {
+	runes := []rune(t.Value)
+	var b strings.Builder
+	last := 0
+	for i, annotation := range t.Annotations {
+		start, end := annotation.StartIndex, annotation.EndIndex
+		if start < last || end < start || end > len(runes) || string(runes[start:end]) != annotation.Text {
+			// Fall back to the next occurrence of the annotation text.
+			rest := string(runes[last:])
+			offset := strings.Index(rest, annotation.Text)
+			if annotation.Text == "" || offset < 0 {
+				continue
+			}
+			start = last + utf8.RuneCountInString(rest[:offset])
+			end = start + utf8.RuneCountInString(annotation.Text)
+		}
+		b.WriteString(string(runes[last:start]))
+		b.WriteString(replace(i, annotation))
+		last = end
+	}
+	b.WriteString(string(runes[last:]))
+	return b.String()
+}
+
+// ResolveCitations replaces every annotation with a numbered reference such as "[1]"
+// and returns the IDs of the referenced files in order, so that callers can render
+// them as footnotes.
+func (t MessageText) ResolveCitations() (text string, fileIDs []string) {
+	text = t.ResolveAnnotations(func(_ int, annotation MessageAnnotation) string {
+		fileIDs = append(fileIDs, annotation.FileID())
+		return fmt.Sprintf("[%d]", len(fileIDs))
+	})
+	return
+}
+
+// MessageDelta represents the changes of a message streamed in a thread.message.delta event.
+type MessageDelta struct {
+	ID     string              `json:"id"`
+	Object string              `json:"object"`
+	Delta  MessageDeltaDetails `json:"delta"`
+}
+
+type MessageDeltaDetails struct {
+	Role    string                `json:"role,omitempty"`
+	Content []MessageDeltaContent `json:"content,omitempty"`
+}
+
+// MessageDeltaContent is a fragment of message content. Index identifies the
+// content part of the message it belongs to.
+type MessageDeltaContent struct {
+	Index     int          `json:"index"`
 	Type      string       `json:"type"`
 	Text      *MessageText `json:"text,omitempty"`
 	ImageFile *ImageFile   `json:"image_file,omitempty"`
 }
-type MessageText struct {
-	Value       string `json:"value"`
-	Annotations []any  `json:"annotations"`
-}
 
 type ImageFile struct {
-	FileID string `json:"file_id"`
+	FileID string         `json:"file_id"`
+	Detail ImageURLDetail `json:"detail,omitempty"`
+}
+
+type MessageImageURL struct {
+	URL    string         `json:"url"`
+	Detail ImageURLDetail `json:"detail,omitempty"`
+}
+
+type MessageContentPartType string
+
+const (
+	MessageContentPartTypeText      MessageContentPartType = "text"
+	MessageContentPartTypeImageFile MessageContentPartType = "image_file"
+	MessageContentPartTypeImageURL  MessageContentPartType = "image_url"
+)
+
+// MessageContentPart is a part of a multi-part message request.
+type MessageContentPart struct {
+	Type      MessageContentPartType `json:"type"`
+	Text      string                 `json:"text,omitempty"`
+	ImageFile *ImageFile             `json:"image_file,omitempty"`
+	ImageURL  *MessageImageURL       `json:"image_url,omitempty"`
 }
 
 type MessageRequest struct {
-	Role     string         `json:"role"`
-	Content  string         `json:"content"`
-	FileIds  []string       `json:"file_ids,omitempty"` //nolint:revive // backwards-compatibility
-	Metadata map[string]any `json:"metadata,omitempty"`
+	Role    string `json:"role"`
+	Content string `json:"content"`
+	// MultiContent is sent as the content of the message instead of Content.
+	MultiContent []MessageContentPart `json:"-"`
+	FileIds      []string             `json:"file_ids,omitempty"` //nolint:revive // backwards-compatibility
+	Attachments  []ThreadAttachment   `json:"attachments,omitempty"`
+	Metadata     map[string]any       `json:"metadata,omitempty"`
+}
+
+func (m MessageRequest) MarshalJSON() ([]byte, error) {
+	if m.Content != "" && m.MultiContent != nil {
+		return nil, ErrContentFieldsMisused
+	}
+	type messageRequest MessageRequest
+	if len(m.MultiContent) > 0 {
+		return json.Marshal(struct {
+			messageRequest
+			MultiContent []MessageContentPart `json:"content"`
+		}{messageRequest(m), m.MultiContent})
+	}
+	return json.Marshal(messageRequest(m))
 }
 
 type MessageFile struct {
@@ -122,6 +285,14 @@ func (c *Client) ListMessage(ctx context.Context, threadID string,
 	return
 }
 
+// ListMessagePager returns a Pager over all messages in the thread.
+func (c *Client) ListMessagePager(ctx context.Context, threadID string, pagination Pagination) *Pager[Message] {
+	return newPager(ctx, pagination, func(ctx context.Context, page Pagination) ([]Message, bool, string, error) {
+		list, err := c.ListMessage(ctx, threadID, page.Limit, page.Order, page.After, page.Before)
+		return list.Messages, list.HasMore, derefString(list.LastID), err
+	})
+}
+
 // RetrieveMessage retrieves a Message.
 func (c *Client) RetrieveMessage(
 	ctx context.Context,
@@ -243,3 +243,51 @@ func TestMessages(t *testing.T) {
 		t.Fatalf("unexpected message file id: '%s' in list message files", msgFiles.MessageFiles[0].ID)
 	}
 }
+
+func TestMessageTextResolveCitations(t *testing.T) {
+	data := `{"value":"Paris【4:0†source】 is big【4:1†source】.","annotations":[` +
+		`{"type":"file_citation","text":"【4:0†source】","start_index":5,"end_index":17,` +
+		`"file_citation":{"file_id":"file_1"}},` +
+		`{"type":"file_path","text":"【4:1†source】","start_index":0,"end_index":0,` +
+		`"file_path":{"file_id":"file_2"}}]}`
+
+	var text openai.MessageText
+	checks.NoError(t, json.Unmarshal([]byte(data), &text), "Unmarshal error")
+	if text.Annotations[0].FileCitation == nil || text.Annotations[0].FileID() != "file_1" {
+		t.Fatalf("unexpected file citation: %+v", text.Annotations[0])
+	}
+
+	// The second annotation has wrong offsets and is located by its text.
+	resolved, fileIDs := text.ResolveCitations()
+	if resolved != "Paris[1] is big[2]." {
+		t.Errorf("unexpected resolved text %q", resolved)
+	}
+	if len(fileIDs) != 2 || fileIDs[1] != "file_2" {
+		t.Errorf("unexpected cited files %v", fileIDs)
+	}
+}
+
+func TestMessageRequestMultiContent(t *testing.T) {
+	request := openai.MessageRequest{
+		Role: "user",
+		MultiContent: []openai.MessageContentPart{
+			{Type: openai.MessageContentPartTypeText, Text: "What is this?"},
+			{Type: openai.MessageContentPartTypeImageFile, ImageFile: &openai.ImageFile{FileID: "file_1"}},
+		},
+		Attachments: []openai.ThreadAttachment{
+			{FileID: "file_2", Tools: []openai.ThreadAttachmentTool{{Type: "file_search"}}},
+		},
+	}
+	data, err := json.Marshal(request)
+	checks.NoError(t, err, "Marshal error")
+
+	expected := `{"role":"user","attachments":[{"file_id":"file_2","tools":[{"type":"file_search"}]}],` +
+		`"content":[{"type":"text","text":"What is this?"},{"type":"image_file","image_file":{"file_id":"file_1"}}]}`
+	if string(data) != expected {
+		t.Errorf("unexpected request JSON %s", data)
+	}
+
+	request.Content = "Hello"
+	_, err = json.Marshal(request)
+	checks.ErrorIs(t, err, openai.ErrContentFieldsMisused, "Marshal did not reject Content with MultiContent")
+}
@@ -0,0 +1,104 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	utils "github.com/neospace-ai/go-openai/internal"
+)
+
+// UploadProgressFunc is called while the body of an upload is sent, with the number of bytes sent so far
+// and the size of the body, or -1 if the size is unknown. It is called from the goroutine sending the request.
+type UploadProgressFunc func(sent, total int64)
+
+// multipartBody is the body of a multipart request, written by a form while it is read.
+type multipartBody struct {
+	reader   *io.PipeReader
+	done     chan error
+	sent     int64
+	total    int64
+	progress UploadProgressFunc
+}
+
+func (b *multipartBody) Read(p []byte) (int, error) {
+	n, err := b.reader.Read(p)
+	if n > 0 {
+		b.sent += int64(n)
+		if b.progress != nil {
+			b.progress(b.sent, b.total)
+		}
+	}
+	return n, err
+}
+
+func (b *multipartBody) Close() error {
+	return b.reader.Close()
+}
+
+// wait stops the form and returns its error, ignoring the error caused by the request no longer
+// reading the body.
+func (b *multipartBody) wait() error {
+	b.reader.Close()
+	err := <-b.done
+	if errors.Is(err, io.ErrClosedPipe) {
+		return nil
+	}
+	return err
+}
+
+// sendMultipartRequest posts the multipart form built by form, which must close the builder.
+// The form is streamed through a pipe rather than buffered, so that files of any size are sent
+// without being loaded into memory. When the sizes of all files of the form are known, the form
+// is built a first time without reading them to set the content length of the request.
+func (c *Client) sendMultipartRequest(
+	ctx context.Context,
+	url string,
+	progress UploadProgressFunc,
+	form func(utils.FormBuilder) error,
+	v Response,
+) error {
+	pr, pw := io.Pipe()
+	builder := c.createFormBuilder(pw)
+
+	size := int64(-1)
+	if b, ok := builder.(*utils.DefaultFormBuilder); ok {
+		sizer := utils.NewFormSizer(b.Boundary())
+		if err := form(sizer); err != nil {
+			return err
+		}
+		if n, known := sizer.Size(); known {
+			size = n
+		}
+	}
+
+	body := &multipartBody{
+		reader:   pr,
+		done:     make(chan error, 1),
+		total:    size,
+		progress: progress,
+	}
+	contentType := builder.FormDataContentType()
+	go func() {
+		err := form(builder)
+		pw.CloseWithError(err)
+		body.done <- err
+	}()
+
+	req, err := c.newRequest(ctx, http.MethodPost, url, withBody(body), withContentType(contentType))
+	if err != nil {
+		_ = body.wait()
+		return err
+	}
+	if size >= 0 {
+		req.ContentLength = size
+	}
+
+	err = c.sendRequest(req, v)
+	// A failing form makes the request fail as well, its error is the cause.
+	if formErr := body.wait(); formErr != nil {
+		return formErr
+	}
+	return err
+}
@@ -1,6 +1,8 @@
 package openai_test
 
 import (
+	"net/http"
+
 	"github.com/neospace-ai/go-openai"
 	"github.com/neospace-ai/go-openai/internal/test"
 )
@@ -12,6 +14,10 @@ func setupOpenAITestServer() (client *openai.Client, server *test.ServerTest, te
 	teardown = ts.Close
 	config := openai.DefaultConfig(test.GetTestToken())
 	config.BaseURL = ts.URL + "/v1"
+	// The test server checks the Authorization header rather than the x-api-key header the client sends.
+	config.HTTPClient = &http.Client{
+		Transport: &test.TokenRoundTripper{Token: test.GetTestToken(), Fallback: http.DefaultTransport},
+	}
 	client = openai.NewClientWithConfig(config)
 	return
 }
@@ -0,0 +1,91 @@
+package openai
+
+import "context"
+
+// pageFetcher fetches a single page of a cursor-paginated list. It returns the items of the
+// page, whether more pages follow, and the cursor to pass as After for the next page.
+type pageFetcher[T any] func(ctx context.Context, pagination Pagination) (
+	items []T, hasMore bool, lastID string, err error)
+
+// Pager iterates over every item of a cursor-paginated list, transparently following the
+// After cursor across pages. The Limit of the initial Pagination is used as the page size
+// hint, and After, Before and Order are forwarded to the first request.
+//
+// A Pager is single-use and not safe for concurrent use:
+//
+//	pager := client.ListAssistantsPager(ctx, openai.Pagination{})
+//	for pager.Next() {
+//		assistant := pager.Current()
+//		...
+//	}
+//	if err := pager.Err(); err != nil {
+//		...
+//	}
+//
+// Iteration stops early once the context is done, and Err then returns the context error.
+type Pager[T any] struct {
+	ctx        context.Context
+	fetch      pageFetcher[T]
+	pagination Pagination
+
+	items   []T
+	current T
+	hasMore bool
+	err     error
+}
+
+func newPager[T any](ctx context.Context, pagination Pagination, fetch pageFetcher[T]) *Pager[T] {
+	return &Pager[T]{
+		ctx:        ctx,
+		fetch:      fetch,
+		pagination: pagination,
+		hasMore:    true,
+	}
+}
+
+// Next advances to the next item, fetching the next page when the current one is exhausted.
+// It returns false when the list is exhausted or an error occurred.
+func (p *Pager[T]) Next() bool {
+	if p.err != nil {
+		return false
+	}
+	if err := p.ctx.Err(); err != nil {
+		p.err = err
+		return false
+	}
+
+	for len(p.items) == 0 {
+		if !p.hasMore {
+			return false
+		}
+		items, hasMore, lastID, err := p.fetch(p.ctx, p.pagination)
+		if err != nil {
+			p.err = err
+			return false
+		}
+		p.items = items
+		p.hasMore = hasMore && lastID != ""
+		p.pagination.After = &lastID
+	}
+
+	p.current = p.items[0]
+	p.items = p.items[1:]
+	return true
+}
+
+// Current returns the item Next advanced to.
+func (p *Pager[T]) Current() T {
+	return p.current
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (p *Pager[T]) Err() error {
+	return p.err
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
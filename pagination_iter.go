@@ -0,0 +1,28 @@
+//go:build go1.23
+
+package openai
+
+import "iter"
+
+// All returns an iterator over the remaining items of the list. A non-nil error is yielded
+// once, as the last element, when fetching a page fails or the context is done.
+//
+//	for assistant, err := range client.ListAssistantsPager(ctx, openai.Pagination{}).All() {
+//		if err != nil {
+//			...
+//		}
+//		...
+//	}
+func (p *Pager[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for p.Next() {
+			if !yield(p.Current(), nil) {
+				return
+			}
+		}
+		if err := p.Err(); err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
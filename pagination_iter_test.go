@@ -0,0 +1,30 @@
+//go:build go1.23
+
+package openai_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neospace-ai/go-openai"
+	"github.com/neospace-ai/go-openai/internal/test/checks"
+)
+
+func TestPagerAll(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	registerPagedAssistants(t, server)
+
+	limit := 2
+	var ids []string
+	for assistant, err := range client.ListAssistantsPager(context.Background(), openai.Pagination{Limit: &limit}).All() {
+		checks.NoErrorF(t, err, "All error")
+		ids = append(ids, assistant.ID)
+		if len(ids) == 2 {
+			break
+		}
+	}
+	if len(ids) != 2 || ids[1] != "asst_2" {
+		t.Errorf("unexpected assistants %v", ids)
+	}
+}
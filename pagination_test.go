@@ -0,0 +1,92 @@
+package openai_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/neospace-ai/go-openai"
+	"github.com/neospace-ai/go-openai/internal/test"
+	"github.com/neospace-ai/go-openai/internal/test/checks"
+)
+
+// registerPagedAssistants serves three assistants in pages of two, following the after cursor.
+func registerPagedAssistants(t *testing.T, server *test.ServerTest) {
+	t.Helper()
+	server.RegisterHandler("/v1/assistants", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("limit") != "2" {
+			t.Errorf("expected limit 2, got %q", r.URL.Query().Get("limit"))
+		}
+		switch r.URL.Query().Get("after") {
+		case "":
+			fmt.Fprintln(w, `{"data":[{"id":"asst_1"},{"id":"asst_2"}],"last_id":"asst_2","has_more":true}`)
+		case "asst_2":
+			fmt.Fprintln(w, `{"data":[{"id":"asst_3"}],"last_id":"asst_3","has_more":false}`)
+		default:
+			t.Errorf("unexpected cursor %q", r.URL.Query().Get("after"))
+		}
+	})
+}
+
+func TestPagerFollowsCursor(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	registerPagedAssistants(t, server)
+
+	limit := 2
+	pager := client.ListAssistantsPager(context.Background(), openai.Pagination{Limit: &limit})
+
+	var ids []string
+	for pager.Next() {
+		ids = append(ids, pager.Current().ID)
+	}
+	checks.NoError(t, pager.Err(), "Pager error")
+	if fmt.Sprint(ids) != "[asst_1 asst_2 asst_3]" {
+		t.Errorf("unexpected assistants %v", ids)
+	}
+}
+
+func TestPagerStopsOnContextCancel(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	registerPagedAssistants(t, server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	limit := 2
+	pager := client.ListAssistantsPager(ctx, openai.Pagination{Limit: &limit})
+
+	if !pager.Next() {
+		t.Fatalf("expected a first assistant, got error %v", pager.Err())
+	}
+	cancel()
+	if pager.Next() {
+		t.Error("Next did not stop after the context was cancelled")
+	}
+	checks.ErrorIs(t, pager.Err(), context.Canceled, "Pager did not return the context error")
+}
+
+func TestListFineTuningJobEventsPager(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/fine_tuning/jobs/ftjob_1/events", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("after") == "" {
+			fmt.Fprintln(w, `{"data":[{"id":"ftevent_1"}],"has_more":true}`)
+			return
+		}
+		if r.URL.Query().Get("after") != "ftevent_1" {
+			t.Errorf("unexpected cursor %q", r.URL.Query().Get("after"))
+		}
+		fmt.Fprintln(w, `{"data":[{"id":"ftevent_2"}],"has_more":false}`)
+	})
+
+	pager := client.ListFineTuningJobEventsPager(context.Background(), "ftjob_1", openai.Pagination{})
+	count := 0
+	for pager.Next() {
+		count++
+	}
+	checks.NoError(t, pager.Err(), "Pager error")
+	if count != 2 {
+		t.Errorf("expected 2 events, got %d", count)
+	}
+}
@@ -28,6 +28,9 @@ type Run struct {
 	Metadata       map[string]any     `json:"metadata"`
 	Usage          Usage              `json:"usage,omitempty"`
 
+	// IncompleteDetails is set when the run ends with status incomplete.
+	IncompleteDetails *RunIncompleteDetails `json:"incomplete_details,omitempty"`
+
 	Temperature *float32 `json:"temperature,omitempty"`
 	// The maximum number of prompt tokens that may be used over the course of the run.
 	// If the run exceeds the number of prompt tokens specified, the run will end with status 'incomplete'.
@@ -55,6 +58,12 @@ const (
 	RunStatusCancelled      RunStatus = "cancelled"
 )
 
+// RunIncompleteDetails explains why a run ended with status incomplete,
+// such as max_prompt_tokens or max_completion_tokens.
+type RunIncompleteDetails struct {
+	Reason string `json:"reason"`
+}
+
 type RunRequiredAction struct {
 	Type              RequiredActionType `json:"type"`
 	SubmitToolOutputs *SubmitToolOutputs `json:"submit_tool_outputs,omitempty"`
@@ -110,6 +119,9 @@ type RunRequest struct {
 	ToolChoice any `json:"tool_choice,omitempty"`
 	// This can be either a string or a ResponseFormat object.
 	ResponseFormat any `json:"response_format,omitempty"`
+
+	// Stream is set by the streaming variants of the run methods.
+	Stream bool `json:"stream,omitempty"`
 }
 
 // ThreadTruncationStrategy defines the truncation strategy to use for the thread.
@@ -146,11 +158,17 @@ type RunModifyRequest struct {
 type RunList struct {
 	Runs []Run `json:"data"`
 
+	FirstID string `json:"first_id"`
+	LastID  string `json:"last_id"`
+	HasMore bool   `json:"has_more"`
+
 	httpHeader
 }
 
 type SubmitToolOutputsRequest struct {
 	ToolOutputs []ToolOutput `json:"tool_outputs"`
+	// Stream is set by SubmitToolOutputsStream.
+	Stream bool `json:"stream,omitempty"`
 }
 
 type ToolOutput struct {
@@ -203,13 +221,88 @@ const (
 type StepDetails struct {
 	Type            RunStepType                 `json:"type"`
 	MessageCreation *StepDetailsMessageCreation `json:"message_creation,omitempty"`
-	ToolCalls       []ToolCall                  `json:"tool_calls,omitempty"`
+	ToolCalls       []RunStepToolCall           `json:"tool_calls,omitempty"`
+}
+
+// RunStepToolCall is a tool call made during a run step. Exactly one of
+// CodeInterpreter, FileSearch and Function is set, depending on Type.
+type RunStepToolCall struct {
+	// Index is set only in run step deltas.
+	Index           *int                        `json:"index,omitempty"`
+	ID              string                      `json:"id"`
+	Type            AssistantToolType           `json:"type"`
+	CodeInterpreter *RunStepCodeInterpreterCall `json:"code_interpreter,omitempty"`
+	FileSearch      *RunStepFileSearchCall      `json:"file_search,omitempty"`
+	Function        *RunStepFunctionCall        `json:"function,omitempty"`
+}
+
+type RunStepCodeInterpreterCall struct {
+	Input   string                         `json:"input"`
+	Outputs []RunStepCodeInterpreterOutput `json:"outputs"`
+}
+
+type RunStepCodeInterpreterOutputType string
+
+const (
+	RunStepCodeInterpreterOutputTypeLogs  RunStepCodeInterpreterOutputType = "logs"
+	RunStepCodeInterpreterOutputTypeImage RunStepCodeInterpreterOutputType = "image"
+)
+
+// RunStepCodeInterpreterOutput is either the text logs or an image produced by the code interpreter.
+type RunStepCodeInterpreterOutput struct {
+	// Index is set only in run step deltas.
+	Index *int                             `json:"index,omitempty"`
+	Type  RunStepCodeInterpreterOutputType `json:"type"`
+	Logs  string                           `json:"logs,omitempty"`
+	Image *ImageFile                       `json:"image,omitempty"`
+}
+
+type RunStepFileSearchCall struct {
+	RankingOptions *FileSearchRankingOptions `json:"ranking_options,omitempty"`
+	// Results are only returned when requested with include[]=step_details.tool_calls[*].file_search.results[*].content.
+	Results []FileSearchResult `json:"results,omitempty"`
+}
+
+type FileSearchRankingOptions struct {
+	Ranker         string  `json:"ranker"`
+	ScoreThreshold float64 `json:"score_threshold"`
+}
+
+// FileSearchResult is a file chunk found by the file_search tool, ranked by Score.
+type FileSearchResult struct {
+	FileID   string                    `json:"file_id"`
+	FileName string                    `json:"file_name"`
+	Score    float64                   `json:"score"`
+	Content  []FileSearchResultContent `json:"content,omitempty"`
+}
+
+type FileSearchResultContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// RunStepFunctionCall is a function call of a run step. Output is set once the tool outputs are submitted.
+type RunStepFunctionCall struct {
+	Name      string  `json:"name"`
+	Arguments string  `json:"arguments"`
+	Output    *string `json:"output"`
 }
 
 type StepDetailsMessageCreation struct {
 	MessageID string `json:"message_id"`
 }
 
+// RunStepDelta represents the changes of a run step streamed in a thread.run.step.delta event.
+type RunStepDelta struct {
+	ID     string              `json:"id"`
+	Object string              `json:"object"`
+	Delta  RunStepDeltaDetails `json:"delta"`
+}
+
+type RunStepDeltaDetails struct {
+	StepDetails StepDetails `json:"step_details"`
+}
+
 // RunStepList is a list of steps.
 type RunStepList struct {
 	RunSteps []RunStep `json:"data"`
@@ -330,6 +423,14 @@ func (c *Client) ListRuns(
 	return
 }
 
+// ListRunsPager returns a Pager over all runs of the thread.
+func (c *Client) ListRunsPager(ctx context.Context, threadID string, pagination Pagination) *Pager[Run] {
+	return newPager(ctx, pagination, func(ctx context.Context, page Pagination) ([]Run, bool, string, error) {
+		list, err := c.ListRuns(ctx, threadID, page)
+		return list.Runs, list.HasMore, list.LastID, err
+	})
+}
+
 // SubmitToolOutputs submits tool outputs.
 func (c *Client) SubmitToolOutputs(
 	ctx context.Context,
@@ -449,3 +550,16 @@ func (c *Client) ListRunSteps(
 	err = c.sendRequest(req, &response)
 	return
 }
+
+// ListRunStepsPager returns a Pager over all steps of the run.
+func (c *Client) ListRunStepsPager(
+	ctx context.Context,
+	threadID string,
+	runID string,
+	pagination Pagination,
+) *Pager[RunStep] {
+	return newPager(ctx, pagination, func(ctx context.Context, page Pagination) ([]RunStep, bool, string, error) {
+		list, err := c.ListRunSteps(ctx, threadID, runID, page)
+		return list.RunSteps, list.HasMore, list.LastID, err
+	})
+}
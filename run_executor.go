@@ -0,0 +1,270 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultRunPollInterval    = 500 * time.Millisecond
+	defaultRunMaxPollInterval = 5 * time.Second
+	defaultRunPollMultiplier  = 1.5
+	runStepsPageSize          = 100
+	// runCancelTimeout bounds the cancellation of a run whose execution stopped early.
+	runCancelTimeout = 10 * time.Second
+)
+
+var (
+	ErrRunFailed              = errors.New("run failed")
+	ErrRunExpired             = errors.New("run expired")
+	ErrRunIncomplete          = errors.New("run incomplete")
+	ErrRunCancelled           = errors.New("run cancelled")
+	ErrRunToolHandlerNotFound = errors.New("no handler registered for tool")
+)
+
+// RunStatusError is returned by RunExecutor when a run ends in a status other than completed.
+// It matches ErrRunFailed, ErrRunExpired, ErrRunIncomplete or ErrRunCancelled with errors.Is.
+type RunStatusError struct {
+	Run               Run
+	LastError         *RunLastError
+	IncompleteDetails *RunIncompleteDetails
+}
+
+func (e *RunStatusError) Error() string {
+	switch {
+	case e.LastError != nil:
+		return fmt.Sprintf("run %s %s: %s: %s", e.Run.ID, e.Run.Status, e.LastError.Code, e.LastError.Message)
+	case e.IncompleteDetails != nil:
+		return fmt.Sprintf("run %s %s: %s", e.Run.ID, e.Run.Status, e.IncompleteDetails.Reason)
+	default:
+		return fmt.Sprintf("run %s %s", e.Run.ID, e.Run.Status)
+	}
+}
+
+func (e *RunStatusError) Unwrap() error {
+	switch e.Run.Status {
+	case RunStatusFailed:
+		return ErrRunFailed
+	case RunStatusExpired:
+		return ErrRunExpired
+	case RunStatusIncomplete:
+		return ErrRunIncomplete
+	case RunStatusCancelled:
+		return ErrRunCancelled
+	default:
+		return nil
+	}
+}
+
+// RunToolHandler computes the output of a single tool call requested by a run.
+type RunToolHandler func(ctx context.Context, call ToolCall) (output string, err error)
+
+// RunResult is the outcome of a run driven to completion by a RunExecutor.
+type RunResult struct {
+	Run Run
+	// Steps are the steps of the run, oldest first.
+	Steps []RunStep
+	// Messages are the messages created by the run, oldest first.
+	Messages []Message
+}
+
+// RunExecutor drives assistant runs to a terminal status. It polls the run with an
+// exponential backoff and answers required actions by calling the registered tool handlers.
+type RunExecutor struct {
+	client *Client
+
+	// Tools maps function names to the handlers that answer their calls.
+	Tools map[string]RunToolHandler
+	// PollInterval is the delay before the first poll. It grows by PollMultiplier
+	// up to MaxPollInterval, and is reset after tool outputs are submitted.
+	// A PollInterval of zero or less and a PollMultiplier under one use the defaults.
+	PollInterval    time.Duration
+	MaxPollInterval time.Duration
+	PollMultiplier  float64
+	// Timeout bounds the whole execution. Zero means no timeout besides the context.
+	Timeout time.Duration
+}
+
+// NewRunExecutor creates a RunExecutor with default polling settings.
+func NewRunExecutor(client *Client, tools map[string]RunToolHandler) *RunExecutor {
+	return &RunExecutor{
+		client:          client,
+		Tools:           tools,
+		PollInterval:    defaultRunPollInterval,
+		MaxPollInterval: defaultRunMaxPollInterval,
+		PollMultiplier:  defaultRunPollMultiplier,
+	}
+}
+
+// CreateAndExecute creates a run on the thread and drives it to completion.
+func (e *RunExecutor) CreateAndExecute(ctx context.Context, threadID string, request RunRequest) (RunResult, error) {
+	run, err := e.client.CreateRun(ctx, threadID, request)
+	if err != nil {
+		return RunResult{}, err
+	}
+	return e.Execute(ctx, run)
+}
+
+// Execute drives an existing run to a terminal status. If the execution stops early,
+// because of a timeout or a failing tool handler, the run is cancelled.
+// Runs that end failed, expired, incomplete or cancelled return a *RunStatusError
+// along with the result.
+func (e *RunExecutor) Execute(ctx context.Context, run Run) (result RunResult, err error) {
+	if e.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.Timeout)
+		defer cancel()
+	}
+
+	run, err = e.poll(ctx, run)
+	if err != nil {
+		if !isTerminalRunStatus(run.Status) {
+			// Best effort, the caller's context may already be done.
+			cancelCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), runCancelTimeout)
+			_, _ = e.client.CancelRun(cancelCtx, run.ThreadID, run.ID)
+			cancel()
+		}
+		return RunResult{Run: run}, err
+	}
+
+	result, err = e.collect(ctx, run)
+	if err != nil {
+		return
+	}
+
+	if run.Status != RunStatusCompleted {
+		err = &RunStatusError{Run: run, LastError: run.LastError, IncompleteDetails: run.IncompleteDetails}
+	}
+	return
+}
+
+func (e *RunExecutor) poll(ctx context.Context, run Run) (Run, error) {
+	initial := e.PollInterval
+	if initial <= 0 {
+		initial = defaultRunPollInterval
+	}
+	multiplier := e.PollMultiplier
+	if multiplier < 1 {
+		multiplier = defaultRunPollMultiplier
+	}
+
+	interval := initial
+	for {
+		switch run.Status {
+		case RunStatusRequiresAction:
+			outputs, err := e.callTools(ctx, run)
+			if err != nil {
+				return run, err
+			}
+			run, err = e.client.SubmitToolOutputs(ctx, run.ThreadID, run.ID, SubmitToolOutputsRequest{
+				ToolOutputs: outputs,
+			})
+			if err != nil {
+				return run, err
+			}
+			interval = initial
+			continue
+		case RunStatusQueued, RunStatusInProgress, RunStatusCancelling:
+		default:
+			return run, nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return run, ctx.Err()
+		case <-timer.C:
+		}
+		interval = time.Duration(float64(interval) * multiplier)
+		if e.MaxPollInterval > 0 && interval > e.MaxPollInterval {
+			interval = e.MaxPollInterval
+		}
+
+		latest, err := e.client.RetrieveRun(ctx, run.ThreadID, run.ID)
+		if err != nil {
+			return run, err
+		}
+		run = latest
+	}
+}
+
+// callTools runs the handlers of all requested tool calls concurrently. No handler is called
+// unless every call has one.
+func (e *RunExecutor) callTools(ctx context.Context, run Run) ([]ToolOutput, error) {
+	if run.RequiredAction == nil || run.RequiredAction.SubmitToolOutputs == nil {
+		return nil, fmt.Errorf("run %s requires an unsupported action", run.ID)
+	}
+
+	calls := run.RequiredAction.SubmitToolOutputs.ToolCalls
+	handlers := make([]RunToolHandler, len(calls))
+	for i, call := range calls {
+		handler, ok := e.Tools[call.Function.Name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrRunToolHandlerNotFound, call.Function.Name)
+		}
+		handlers[i] = handler
+	}
+
+	outputs := make([]ToolOutput, len(calls))
+	errs := make([]error, len(calls))
+	var wg sync.WaitGroup
+	for i, call := range calls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			output, err := handlers[i](ctx, call)
+			if err != nil {
+				errs[i] = fmt.Errorf("tool %s: %w", call.Function.Name, err)
+				return
+			}
+			outputs[i] = ToolOutput{
+				ToolCallID: call.ID,
+				Output:     output,
+			}
+		}()
+	}
+	wg.Wait()
+
+	return outputs, errors.Join(errs...)
+}
+
+// collect lists the steps of a finished run and retrieves the messages they created.
+func (e *RunExecutor) collect(ctx context.Context, run Run) (result RunResult, err error) {
+	result.Run = run
+
+	limit := runStepsPageSize
+	order := "asc"
+	steps := e.client.ListRunStepsPager(ctx, run.ThreadID, run.ID, Pagination{Limit: &limit, Order: &order})
+	for steps.Next() {
+		result.Steps = append(result.Steps, steps.Current())
+	}
+	if err = steps.Err(); err != nil {
+		return
+	}
+
+	for _, step := range result.Steps {
+		if step.StepDetails.MessageCreation == nil {
+			continue
+		}
+		var msg Message
+		msg, err = e.client.RetrieveMessage(ctx, run.ThreadID, step.StepDetails.MessageCreation.MessageID)
+		if err != nil {
+			return
+		}
+		result.Messages = append(result.Messages, msg)
+	}
+	return
+}
+
+func isTerminalRunStatus(status RunStatus) bool {
+	switch status {
+	case RunStatusCompleted, RunStatusFailed, RunStatusIncomplete, RunStatusExpired, RunStatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
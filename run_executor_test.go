@@ -0,0 +1,225 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/neospace-ai/go-openai"
+	"github.com/neospace-ai/go-openai/internal/test/checks"
+)
+
+func TestRunExecutor(t *testing.T) {
+	threadID := "thread_abc123"
+	runID := "run_abc123"
+	messageID := "msg_abc123"
+
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	newRun := func(status openai.RunStatus) openai.Run {
+		return openai.Run{ID: runID, ThreadID: threadID, Status: status}
+	}
+	writeJSON := func(w http.ResponseWriter, v any) {
+		resBytes, _ := json.Marshal(v)
+		fmt.Fprintln(w, string(resBytes))
+	}
+
+	server.RegisterHandler("/v1/threads/"+threadID+"/runs", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, newRun(openai.RunStatusQueued))
+	})
+
+	polls := 0
+	submitted := false
+	server.RegisterHandler("/v1/threads/"+threadID+"/runs/"+runID, func(w http.ResponseWriter, _ *http.Request) {
+		polls++
+		switch {
+		case submitted:
+			writeJSON(w, newRun(openai.RunStatusCompleted))
+		case polls == 1:
+			writeJSON(w, newRun(openai.RunStatusInProgress))
+		default:
+			run := newRun(openai.RunStatusRequiresAction)
+			run.RequiredAction = &openai.RunRequiredAction{
+				Type: openai.RequiredActionTypeSubmitToolOutputs,
+				SubmitToolOutputs: &openai.SubmitToolOutputs{
+					ToolCalls: []openai.ToolCall{
+						{ID: "call_1", Type: openai.ToolTypeFunction, Function: openai.FunctionCall{Name: "weather"}},
+						{ID: "call_2", Type: openai.ToolTypeFunction, Function: openai.FunctionCall{Name: "weather"}},
+					},
+				},
+			}
+			writeJSON(w, run)
+		}
+	})
+
+	server.RegisterHandler(
+		"/v1/threads/"+threadID+"/runs/"+runID+"/submit_tool_outputs",
+		func(w http.ResponseWriter, r *http.Request) {
+			var request openai.SubmitToolOutputsRequest
+			checks.NoError(t, json.NewDecoder(r.Body).Decode(&request), "Decode error")
+			if len(request.ToolOutputs) != 2 || request.ToolOutputs[1].ToolCallID != "call_2" ||
+				request.ToolOutputs[1].Output != "sunny" {
+				t.Errorf("unexpected tool outputs: %+v", request.ToolOutputs)
+			}
+			submitted = true
+			writeJSON(w, newRun(openai.RunStatusQueued))
+		},
+	)
+
+	server.RegisterHandler("/v1/threads/"+threadID+"/runs/"+runID+"/steps", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, openai.RunStepList{
+			RunSteps: []openai.RunStep{
+				{ID: "step_1", Type: openai.RunStepTypeToolCalls},
+				{
+					ID:   "step_2",
+					Type: openai.RunStepTypeMessageCreation,
+					StepDetails: openai.StepDetails{
+						Type:            openai.RunStepTypeMessageCreation,
+						MessageCreation: &openai.StepDetailsMessageCreation{MessageID: messageID},
+					},
+				},
+			},
+		})
+	})
+
+	server.RegisterHandler("/v1/threads/"+threadID+"/messages/"+messageID, func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, openai.Message{ID: messageID, Role: openai.ChatMessageRoleAssistant})
+	})
+
+	executor := openai.NewRunExecutor(client, map[string]openai.RunToolHandler{
+		"weather": func(context.Context, openai.ToolCall) (string, error) {
+			return "sunny", nil
+		},
+	})
+	executor.PollInterval = time.Millisecond
+
+	result, err := executor.CreateAndExecute(context.Background(), threadID, openai.RunRequest{
+		AssistantID: "asst_abc123",
+	})
+	checks.NoError(t, err, "CreateAndExecute error")
+	if result.Run.Status != openai.RunStatusCompleted {
+		t.Errorf("expected completed run, got %s", result.Run.Status)
+	}
+	if len(result.Steps) != 2 {
+		t.Errorf("expected 2 steps, got %d", len(result.Steps))
+	}
+	if len(result.Messages) != 1 || result.Messages[0].ID != messageID {
+		t.Errorf("unexpected messages: %+v", result.Messages)
+	}
+}
+
+func TestRunExecutorFailedRun(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	server.RegisterHandler(
+		"/v1/threads/thread_abc123/runs/run_abc123/steps",
+		func(w http.ResponseWriter, _ *http.Request) {
+			fmt.Fprintln(w, `{"data":[]}`)
+		},
+	)
+
+	executor := openai.NewRunExecutor(client, nil)
+	result, err := executor.Execute(context.Background(), openai.Run{
+		ID:       "run_abc123",
+		ThreadID: "thread_abc123",
+		Status:   openai.RunStatusFailed,
+		LastError: &openai.RunLastError{
+			Code:    openai.RunErrorRateLimitExceeded,
+			Message: "slow down",
+		},
+	})
+	checks.ErrorIs(t, err, openai.ErrRunFailed, "Execute did not return ErrRunFailed")
+
+	var statusErr *openai.RunStatusError
+	if !errors.As(err, &statusErr) || statusErr.LastError.Code != openai.RunErrorRateLimitExceeded {
+		t.Errorf("Execute did not return a RunStatusError with the last error: %v", err)
+	}
+	if result.Run.Status != openai.RunStatusFailed {
+		t.Errorf("expected the failed run in the result, got %s", result.Run.Status)
+	}
+}
+
+func TestRunExecutorMissingToolHandler(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	cancelled := false
+	server.RegisterHandler(
+		"/v1/threads/thread_abc123/runs/run_abc123/cancel",
+		func(w http.ResponseWriter, _ *http.Request) {
+			cancelled = true
+			fmt.Fprintln(w, `{"id":"run_abc123","status":"cancelling"}`)
+		},
+	)
+
+	called := false
+	executor := openai.NewRunExecutor(client, map[string]openai.RunToolHandler{
+		"known": func(context.Context, openai.ToolCall) (string, error) {
+			called = true
+			return "", nil
+		},
+	})
+	executor.PollInterval = 0
+	_, err := executor.Execute(context.Background(), openai.Run{
+		ID:       "run_abc123",
+		ThreadID: "thread_abc123",
+		Status:   openai.RunStatusRequiresAction,
+		RequiredAction: &openai.RunRequiredAction{
+			Type: openai.RequiredActionTypeSubmitToolOutputs,
+			SubmitToolOutputs: &openai.SubmitToolOutputs{
+				ToolCalls: []openai.ToolCall{
+					{ID: "call_1", Function: openai.FunctionCall{Name: "known"}},
+					{ID: "call_2", Function: openai.FunctionCall{Name: "unknown"}},
+				},
+			},
+		},
+	})
+	checks.ErrorIs(t, err, openai.ErrRunToolHandlerNotFound, "Execute did not return ErrRunToolHandlerNotFound")
+	if !cancelled {
+		t.Error("Execute did not cancel the run")
+	}
+	if called {
+		t.Error("Execute called a handler although another one is missing")
+	}
+}
+
+func TestRunExecutorDefaultsPollInterval(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var polls atomic.Int32
+	server.RegisterHandler(
+		"/v1/threads/thread_abc123/runs/run_abc123",
+		func(w http.ResponseWriter, _ *http.Request) {
+			polls.Add(1)
+			fmt.Fprintln(w, `{"id":"run_abc123","thread_id":"thread_abc123","status":"queued"}`)
+		},
+	)
+	server.RegisterHandler(
+		"/v1/threads/thread_abc123/runs/run_abc123/cancel",
+		func(w http.ResponseWriter, _ *http.Request) {
+			fmt.Fprintln(w, `{"id":"run_abc123","status":"cancelling"}`)
+		},
+	)
+
+	executor := openai.NewRunExecutor(client, nil)
+	executor.PollInterval = 0
+	executor.PollMultiplier = 0
+	executor.Timeout = 100 * time.Millisecond
+	_, err := executor.Execute(context.Background(), openai.Run{
+		ID:       "run_abc123",
+		ThreadID: "thread_abc123",
+		Status:   openai.RunStatusQueued,
+	})
+	checks.ErrorIs(t, err, context.DeadlineExceeded, "Execute did not time out")
+	if n := polls.Load(); n != 0 {
+		t.Errorf("expected no poll before the default interval, got %d", n)
+	}
+}
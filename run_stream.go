@@ -0,0 +1,158 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	utils "github.com/neospace-ai/go-openai/internal"
+)
+
+// AssistantStreamEventType is the name of a server-sent event emitted by the Assistants API.
+// https://platform.openai.com/docs/api-reference/assistants-streaming/events
+type AssistantStreamEventType string
+
+const (
+	AssistantStreamEventThreadCreated = AssistantStreamEventType("thread.created")
+
+	AssistantStreamEventRunCreated        = AssistantStreamEventType("thread.run.created")
+	AssistantStreamEventRunQueued         = AssistantStreamEventType("thread.run.queued")
+	AssistantStreamEventRunInProgress     = AssistantStreamEventType("thread.run.in_progress")
+	AssistantStreamEventRunRequiresAction = AssistantStreamEventType("thread.run.requires_action")
+	AssistantStreamEventRunCompleted      = AssistantStreamEventType("thread.run.completed")
+	AssistantStreamEventRunIncomplete     = AssistantStreamEventType("thread.run.incomplete")
+	AssistantStreamEventRunFailed         = AssistantStreamEventType("thread.run.failed")
+	AssistantStreamEventRunCancelling     = AssistantStreamEventType("thread.run.cancelling")
+	AssistantStreamEventRunCancelled      = AssistantStreamEventType("thread.run.cancelled")
+	AssistantStreamEventRunExpired        = AssistantStreamEventType("thread.run.expired")
+
+	AssistantStreamEventRunStepCreated    = AssistantStreamEventType("thread.run.step.created")
+	AssistantStreamEventRunStepInProgress = AssistantStreamEventType("thread.run.step.in_progress")
+	AssistantStreamEventRunStepDelta      = AssistantStreamEventType("thread.run.step.delta")
+	AssistantStreamEventRunStepCompleted  = AssistantStreamEventType("thread.run.step.completed")
+	AssistantStreamEventRunStepFailed     = AssistantStreamEventType("thread.run.step.failed")
+	AssistantStreamEventRunStepCancelled  = AssistantStreamEventType("thread.run.step.cancelled")
+	AssistantStreamEventRunStepExpired    = AssistantStreamEventType("thread.run.step.expired")
+
+	AssistantStreamEventMessageCreated    = AssistantStreamEventType("thread.message.created")
+	AssistantStreamEventMessageInProgress = AssistantStreamEventType("thread.message.in_progress")
+	AssistantStreamEventMessageDelta      = AssistantStreamEventType("thread.message.delta")
+	AssistantStreamEventMessageCompleted  = AssistantStreamEventType("thread.message.completed")
+	AssistantStreamEventMessageIncomplete = AssistantStreamEventType("thread.message.incomplete")
+
+	AssistantStreamEventError = AssistantStreamEventType("error")
+	AssistantStreamEventDone  = AssistantStreamEventType("done")
+)
+
+// AssistantStreamEvent is a typed event of an Assistants run stream.
+// Exactly one of the payload fields is set, depending on Event.
+type AssistantStreamEvent struct {
+	Event AssistantStreamEventType
+
+	Thread       *Thread
+	Run          *Run
+	RunStep      *RunStep
+	RunStepDelta *RunStepDelta
+	Message      *Message
+	MessageDelta *MessageDelta
+}
+
+func (e *AssistantStreamEvent) unmarshalStreamEvent(event StreamEvent, unmarshaler utils.Unmarshaler) error {
+	e.Event = AssistantStreamEventType(event.Event)
+
+	var payload any
+	switch {
+	case e.Event == AssistantStreamEventThreadCreated:
+		e.Thread = &Thread{}
+		payload = e.Thread
+	case e.Event == AssistantStreamEventRunStepDelta:
+		e.RunStepDelta = &RunStepDelta{}
+		payload = e.RunStepDelta
+	case e.Event == AssistantStreamEventMessageDelta:
+		e.MessageDelta = &MessageDelta{}
+		payload = e.MessageDelta
+	case strings.HasPrefix(string(e.Event), "thread.run.step."):
+		e.RunStep = &RunStep{}
+		payload = e.RunStep
+	case strings.HasPrefix(string(e.Event), "thread.run."):
+		e.Run = &Run{}
+		payload = e.Run
+	case strings.HasPrefix(string(e.Event), "thread.message."):
+		e.Message = &Message{}
+		payload = e.Message
+	case e.Event == AssistantStreamEventError:
+		apiErr := &APIError{}
+		if err := unmarshaler.Unmarshal(event.Data, apiErr); err != nil {
+			return err
+		}
+		return fmt.Errorf("error, %w", apiErr)
+	default:
+		// Unknown events are returned with only their name set, so that new
+		// event types do not break existing consumers.
+		return nil
+	}
+	return unmarshaler.Unmarshal(event.Data, payload)
+}
+
+// AssistantStream is a stream of Assistants run events.
+type AssistantStream struct {
+	*streamReader[AssistantStreamEvent]
+}
+
+// CreateRunStream creates a new run and streams its events.
+func (c *Client) CreateRunStream(
+	ctx context.Context,
+	threadID string,
+	request RunRequest,
+) (stream *AssistantStream, err error) {
+	request.Stream = true
+	urlSuffix := fmt.Sprintf("/threads/%s/runs", threadID)
+	return c.createAssistantStream(ctx, urlSuffix, request)
+}
+
+// CreateThreadAndRunStream creates a thread, runs it and streams the run events.
+func (c *Client) CreateThreadAndRunStream(
+	ctx context.Context,
+	request CreateThreadAndRunRequest,
+) (stream *AssistantStream, err error) {
+	request.Stream = true
+	return c.createAssistantStream(ctx, "/threads/runs", request)
+}
+
+// SubmitToolOutputsStream submits tool outputs and streams the events of the resumed run.
+func (c *Client) SubmitToolOutputsStream(
+	ctx context.Context,
+	threadID string,
+	runID string,
+	request SubmitToolOutputsRequest,
+) (stream *AssistantStream, err error) {
+	request.Stream = true
+	urlSuffix := fmt.Sprintf("/threads/%s/runs/%s/submit_tool_outputs", threadID, runID)
+	return c.createAssistantStream(ctx, urlSuffix, request)
+}
+
+func (c *Client) createAssistantStream(
+	ctx context.Context,
+	urlSuffix string,
+	request any,
+) (stream *AssistantStream, err error) {
+	req, err := c.newRequest(
+		ctx,
+		http.MethodPost,
+		c.fullURL(urlSuffix),
+		withBody(request),
+		withBetaAssistantVersion(c.config.AssistantVersion))
+	if err != nil {
+		return
+	}
+
+	resp, err := sendRequestStream[AssistantStreamEvent](c, req)
+	if err != nil {
+		return
+	}
+	stream = &AssistantStream{
+		streamReader: resp,
+	}
+	return
+}
@@ -0,0 +1,150 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/neospace-ai/go-openai"
+	"github.com/neospace-ai/go-openai/internal/test/checks"
+)
+
+const runStreamBody = "event: thread.run.created\n" +
+	`data: {"id":"run_abc123","object":"thread.run","status":"queued"}` + "\n\n" +
+	"event: thread.run.step.created\n" +
+	`data: {"id":"step_abc123","object":"thread.run.step","type":"message_creation"}` + "\n\n" +
+	"event: thread.message.delta\n" +
+	`data: {"id":"msg_abc123","object":"thread.message.delta",` +
+	`"delta":{"content":[{"index":0,"type":"text","text":{"value":"Hello"}}]}}` + "\n\n" +
+	"event: thread.run.step.delta\n" +
+	`data: {"id":"step_abc123","object":"thread.run.step.delta",` +
+	`"delta":{"step_details":{"type":"tool_calls","tool_calls":[{"id":"call_1","type":"function"}]}}}` + "\n\n" +
+	"event: thread.message.completed\n" +
+	`data: {"id":"msg_abc123","object":"thread.message","role":"assistant"}` + "\n\n" +
+	"event: thread.run.requires_action\n" +
+	`data: {"id":"run_abc123","object":"thread.run","status":"requires_action"}` + "\n\n" +
+	"event: done\n" +
+	"data: [DONE]\n\n"
+
+func TestCreateRunStream(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/threads/thread_abc123/runs", func(w http.ResponseWriter, r *http.Request) {
+		var request map[string]any
+		checks.NoError(t, json.NewDecoder(r.Body).Decode(&request), "Decode error")
+		if request["stream"] != true {
+			t.Errorf("expected stream to be true, got %v", request["stream"])
+		}
+		if r.Header.Get("OpenAI-Beta") != "assistants=v2" {
+			t.Errorf("unexpected OpenAI-Beta header %q", r.Header.Get("OpenAI-Beta"))
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, err := w.Write([]byte(runStreamBody))
+		checks.NoError(t, err, "Write error")
+	})
+
+	stream, err := client.CreateRunStream(context.Background(), "thread_abc123", openai.RunRequest{
+		AssistantID: "asst_abc123",
+	})
+	checks.NoErrorF(t, err, "CreateRunStream error")
+	defer stream.Close()
+
+	var events []openai.AssistantStreamEvent
+	for {
+		event, recvErr := stream.Recv()
+		if errors.Is(recvErr, io.EOF) {
+			break
+		}
+		checks.NoErrorF(t, recvErr, "Recv error")
+		events = append(events, event)
+	}
+
+	if len(events) != 6 {
+		t.Fatalf("expected 6 events, got %d", len(events))
+	}
+	if events[0].Event != openai.AssistantStreamEventRunCreated || events[0].Run == nil ||
+		events[0].Run.Status != openai.RunStatusQueued {
+		t.Errorf("unexpected run created event: %+v", events[0])
+	}
+	if events[1].RunStep == nil || events[1].RunStep.Type != openai.RunStepTypeMessageCreation {
+		t.Errorf("unexpected run step event: %+v", events[1])
+	}
+	if events[2].MessageDelta == nil || events[2].MessageDelta.Delta.Content[0].Text.Value != "Hello" {
+		t.Errorf("unexpected message delta event: %+v", events[2])
+	}
+	if events[3].RunStepDelta == nil || events[3].RunStepDelta.Delta.StepDetails.ToolCalls[0].ID != "call_1" {
+		t.Errorf("unexpected run step delta event: %+v", events[3])
+	}
+	if events[4].Message == nil || events[4].Message.Role != openai.ChatMessageRoleAssistant {
+		t.Errorf("unexpected message event: %+v", events[4])
+	}
+	if events[5].Run == nil || events[5].Run.Status != openai.RunStatusRequiresAction {
+		t.Errorf("unexpected requires action event: %+v", events[5])
+	}
+}
+
+func TestSubmitToolOutputsStreamError(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler(
+		"/v1/threads/thread_abc123/runs/run_abc123/submit_tool_outputs",
+		func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			dataBytes := []byte("event: error\n" +
+				`data: {"message":"run expired","type":"server_error"}` + "\n\n")
+			_, err := w.Write(dataBytes)
+			checks.NoError(t, err, "Write error")
+		},
+	)
+
+	stream, err := client.SubmitToolOutputsStream(context.Background(), "thread_abc123", "run_abc123",
+		openai.SubmitToolOutputsRequest{})
+	checks.NoErrorF(t, err, "SubmitToolOutputsStream error")
+	defer stream.Close()
+
+	_, err = stream.Recv()
+	var apiErr *openai.APIError
+	if !errors.As(err, &apiErr) || apiErr.Message != "run expired" {
+		t.Errorf("Recv did not return the streamed APIError: %v", err)
+	}
+}
+
+func TestCreateThreadAndRunStream(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/threads/runs", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		dataBytes := []byte("event: thread.created\n" +
+			`data: {"id":"thread_abc123","object":"thread"}` + "\n\n" +
+			"event: thread.run.unknown_future_event\n" +
+			`data: {}` + "\n\n" +
+			"event: done\ndata: [DONE]\n\n")
+		_, err := w.Write(dataBytes)
+		checks.NoError(t, err, "Write error")
+	})
+
+	stream, err := client.CreateThreadAndRunStream(context.Background(), openai.CreateThreadAndRunRequest{
+		RunRequest: openai.RunRequest{AssistantID: "asst_abc123"},
+	})
+	checks.NoErrorF(t, err, "CreateThreadAndRunStream error")
+	defer stream.Close()
+
+	event, err := stream.Recv()
+	checks.NoErrorF(t, err, "Recv error")
+	if event.Thread == nil || event.Thread.ID != "thread_abc123" {
+		t.Errorf("unexpected thread created event: %+v", event)
+	}
+
+	event, err = stream.Recv()
+	checks.NoErrorF(t, err, "Recv error")
+	if event.Event != "thread.run.unknown_future_event" {
+		t.Errorf("unexpected event name %q", event.Event)
+	}
+
+	_, err = stream.Recv()
+	checks.ErrorIs(t, err, io.EOF, "Recv did not return EOF after done")
+}
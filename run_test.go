@@ -235,3 +235,31 @@ func TestRun(t *testing.T) {
 	)
 	checks.NoError(t, err, "ListRunSteps error")
 }
+
+func TestRunStepToolCalls(t *testing.T) {
+	data := `{"type":"tool_calls","tool_calls":[` +
+		`{"id":"call_1","type":"code_interpreter","code_interpreter":{"input":"print(1)","outputs":[` +
+		`{"type":"logs","logs":"1"},{"type":"image","image":{"file_id":"file_1"}}]}},` +
+		`{"id":"call_2","type":"file_search","file_search":{"results":[` +
+		`{"file_id":"file_2","file_name":"doc.pdf","score":0.9,"content":[{"type":"text","text":"chunk"}]}]}},` +
+		`{"id":"call_3","type":"function","function":{"name":"weather","arguments":"{}","output":"sunny"}}]}`
+
+	var details openai.StepDetails
+	checks.NoError(t, json.Unmarshal([]byte(data), &details), "Unmarshal error")
+	if len(details.ToolCalls) != 3 {
+		t.Fatalf("expected 3 tool calls, got %d", len(details.ToolCalls))
+	}
+
+	code := details.ToolCalls[0].CodeInterpreter
+	if code == nil || code.Outputs[0].Logs != "1" || code.Outputs[1].Image.FileID != "file_1" {
+		t.Errorf("unexpected code interpreter call: %+v", details.ToolCalls[0])
+	}
+	search := details.ToolCalls[1].FileSearch
+	if search == nil || search.Results[0].Score != 0.9 || search.Results[0].Content[0].Text != "chunk" {
+		t.Errorf("unexpected file search call: %+v", details.ToolCalls[1])
+	}
+	function := details.ToolCalls[2].Function
+	if function == nil || function.Output == nil || *function.Output != "sunny" {
+		t.Errorf("unexpected function call: %+v", details.ToolCalls[2])
+	}
+}
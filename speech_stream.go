@@ -0,0 +1,331 @@
+package openai
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// maxSpeechInputLength is the maximum number of characters of the input of a speech request.
+	maxSpeechInputLength = 4096
+	// maxSpeechWAVHeaderSize bounds the bytes buffered while looking for the samples of a WAV response.
+	maxSpeechWAVHeaderSize = 64 << 10
+	speechStreamBufferSize = 32 << 10
+)
+
+// speechPCMFormat is the format of the samples of the pcm response format.
+var speechPCMFormat = PCMFormat{SampleRate: 24000, Channels: 1, BitsPerSample: 16}
+
+// SpeechChunk is a piece of the audio of a speech stream.
+type SpeechChunk struct {
+	// Part is the index of the part of the input the audio is of.
+	Part int
+	// Data is the audio as received. For the pcm and wav response formats, it holds whole frames of
+	// samples, without the WAV header.
+	Data []byte
+	// Format is the format of the samples for the pcm and wav response formats, and zero otherwise.
+	Format PCMFormat
+}
+
+// Samples returns the interleaved samples of the chunk, between -1 and 1, or nil for the response
+// formats other than pcm and wav.
+func (c SpeechChunk) Samples() []float32 {
+	if c.Format.SampleRate == 0 {
+		return nil
+	}
+	sampleSize := c.Format.BitsPerSample / 8
+	samples := make([]float32, len(c.Data)/sampleSize)
+	for i := range samples {
+		samples[i] = float32(c.Format.sample(c.Data[i*sampleSize:], 0))
+	}
+	return samples
+}
+
+type speechResult struct {
+	response RawResponse
+	err      error
+}
+
+// SpeechStream is the audio of a speech request, received as it is generated. Inputs longer than
+// the API accepts are split at sentence boundaries into parts spoken by consecutive requests, and
+// the audio of the parts follows without gaps: the request of a part is sent while the audio of the
+// previous one is received, and the WAV headers of the parts after the first are removed.
+//
+// The audio of the mp3, opus and aac response formats can be played as one stream, while flac
+// decoders may stop at the end of the first part.
+//
+// The requests of the stream are released once Recv returns io.EOF or Close is called. Close must
+// be called when the stream is not read to the end.
+type SpeechStream struct {
+	client  *Client
+	ctx     context.Context
+	cancel  context.CancelFunc
+	request CreateSpeechRequest
+	parts   []string
+
+	part   int
+	body   io.ReadCloser
+	next   chan speechResult
+	format PCMFormat
+	// header is set while the WAV header of the current part has not been received.
+	header bool
+	// remaining is the number of bytes of samples of the current part left to receive, as declared
+	// by its WAV header, or -1 when the size is not known.
+	remaining int
+	pending   []byte
+	buf       []byte
+
+	httpHeader
+}
+
+// CreateSpeechStream sends a speech request and returns its audio as it is generated. The response
+// headers are those of the first part.
+func (c *Client) CreateSpeechStream(ctx context.Context, request CreateSpeechRequest) (*SpeechStream, error) {
+	parts := splitSpeechInput(request.Input, maxSpeechInputLength)
+	if len(parts) == 0 {
+		parts = []string{request.Input}
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	stream := &SpeechStream{
+		client:  c,
+		ctx:     streamCtx,
+		cancel:  cancel,
+		request: request,
+		parts:   parts,
+		buf:     make([]byte, speechStreamBufferSize),
+	}
+	response, err := c.CreateSpeech(streamCtx, stream.partRequest(0))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	stream.httpHeader = response.httpHeader
+	stream.start(response)
+	return stream, nil
+}
+
+func (s *SpeechStream) partRequest(part int) CreateSpeechRequest {
+	request := s.request
+	request.Input = s.parts[part]
+	return request
+}
+
+// start begins receiving the audio of the current part and sends the request of the next one.
+func (s *SpeechStream) start(response RawResponse) {
+	s.body = response.ReadCloser
+	// A partial frame at the end of the previous part is dropped.
+	s.pending = nil
+	s.header = s.request.ResponseFormat == SpeechResponseFormatWav
+	s.remaining = -1
+	if s.request.ResponseFormat == SpeechResponseFormatPcm {
+		s.format = speechPCMFormat
+	}
+
+	s.next = nil
+	if s.part+1 < len(s.parts) {
+		next := make(chan speechResult, 1)
+		request := s.partRequest(s.part + 1)
+		go func() {
+			response, err := s.client.CreateSpeech(s.ctx, request)
+			next <- speechResult{response: response, err: err}
+		}()
+		s.next = next
+	}
+}
+
+// Recv returns the next chunk of audio. It returns io.EOF once the audio of every part was received.
+func (s *SpeechStream) Recv() (SpeechChunk, error) {
+	for s.body != nil {
+		n, err := s.body.Read(s.buf)
+		if n > 0 {
+			chunk, ok, decodeErr := s.decode(s.buf[:n])
+			if decodeErr != nil {
+				return SpeechChunk{}, decodeErr
+			}
+			if ok {
+				return chunk, nil
+			}
+		}
+		if err == io.EOF {
+			err = s.advance()
+		}
+		if err != nil {
+			return SpeechChunk{}, err
+		}
+	}
+	return SpeechChunk{}, io.EOF
+}
+
+// decode returns the chunk of the received data, and whether it holds any audio.
+func (s *SpeechStream) decode(data []byte) (SpeechChunk, bool, error) {
+	chunk := SpeechChunk{Part: s.part}
+	if s.request.ResponseFormat != SpeechResponseFormatWav && s.request.ResponseFormat != SpeechResponseFormatPcm {
+		chunk.Data = append([]byte(nil), data...)
+		return chunk, true, nil
+	}
+
+	if s.header {
+		data = append(s.pending, data...)
+		format, samples, err := parseWAV(data)
+		if err != nil {
+			// The header may not have been received entirely yet.
+			if len(data) > maxSpeechWAVHeaderSize {
+				return chunk, false, err
+			}
+			s.pending = data
+			return chunk, false, nil
+		}
+		if s.part > 0 && format != s.format {
+			return chunk, false, fmt.Errorf("%w: part %d is %+v, not %+v", ErrUnsupportedAudio, s.part, format, s.format)
+		}
+		s.format, s.header, s.pending = format, false, nil
+		// The samples are a slice of data, which may end with a partial frame, and follow the size of
+		// the data chunk. Streamed WAV files do not know their size, and leave it zero or at its maximum.
+		offset := cap(data) - cap(samples)
+		if size := binary.LittleEndian.Uint32(data[offset-4:]); size != 0 && size != math.MaxUint32 {
+			s.remaining = int(size)
+		}
+		data = data[offset:]
+	}
+	// The chunks following the data chunk are not samples.
+	if s.remaining >= 0 {
+		data = data[:min(len(data), s.remaining)]
+		s.remaining -= len(data)
+	}
+
+	data = append(s.pending, data...)
+	whole := len(data) - len(data)%s.format.FrameSize()
+	s.pending = append([]byte(nil), data[whole:]...)
+	chunk.Data, chunk.Format = data[:whole], s.format
+	return chunk, whole > 0, nil
+}
+
+// advance moves to the next part once the audio of the current one was received.
+func (s *SpeechStream) advance() error {
+	s.body.Close()
+	s.body = nil
+	if s.header {
+		_, _, err := parseWAV(s.pending)
+		return err
+	}
+	if s.next == nil {
+		// The audio of every part was received.
+		s.cancel()
+		return nil
+	}
+
+	result := <-s.next
+	s.next = nil
+	if result.err != nil {
+		return fmt.Errorf("speech of part %d: %w", s.part+1, result.err)
+	}
+	s.part++
+	s.start(result.response)
+	return nil
+}
+
+// Close cancels the requests of the stream and releases its resources.
+func (s *SpeechStream) Close() error {
+	s.cancel()
+	var err error
+	if s.body != nil {
+		err = s.body.Close()
+		s.body = nil
+	}
+	if s.next != nil {
+		if result := <-s.next; result.err == nil {
+			result.response.Close()
+		}
+		s.next = nil
+	}
+	return err
+}
+
+// splitSpeechInput splits text into parts of at most maxLength characters, made of whole sentences
+// when possible. Sentences longer than maxLength are split between words.
+func splitSpeechInput(text string, maxLength int) []string {
+	var (
+		parts  []string
+		part   strings.Builder
+		length int
+	)
+	flush := func() {
+		if s := strings.TrimSpace(part.String()); s != "" {
+			parts = append(parts, s)
+		}
+		part.Reset()
+		length = 0
+	}
+
+	for _, sentence := range splitSentences(text) {
+		if length > 0 && length+utf8.RuneCountInString(sentence) > maxLength {
+			flush()
+		}
+		if length == 0 {
+			sentence = strings.TrimLeftFunc(sentence, unicode.IsSpace)
+		}
+		n := utf8.RuneCountInString(sentence)
+		for n > maxLength {
+			head := cutSpeechInput(sentence, maxLength)
+			part.WriteString(head)
+			flush()
+			sentence = strings.TrimLeftFunc(sentence[len(head):], unicode.IsSpace)
+			n = utf8.RuneCountInString(sentence)
+		}
+		part.WriteString(sentence)
+		length += n
+	}
+	flush()
+	return parts
+}
+
+// splitSentences splits text after the punctuation ending sentences and after line breaks. Every
+// sentence but the first starts with the whitespace that preceded it.
+func splitSentences(text string) []string {
+	var sentences []string
+	start := 0
+	end := false
+	for i, r := range text {
+		switch {
+		case (r == '\n' || (end && unicode.IsSpace(r))) && strings.TrimSpace(text[start:i]) != "":
+			sentences = append(sentences, text[start:i])
+			start, end = i, false
+		case strings.ContainsRune(".!?…。！？", r):
+			end = true
+		case !unicode.IsSpace(r) && !strings.ContainsRune(`"')]}»”’`, r):
+			end = false
+		}
+	}
+	if start < len(text) {
+		sentences = append(sentences, text[start:])
+	}
+	return sentences
+}
+
+// cutSpeechInput returns the longest prefix of text of at most maxLength characters ending at a word
+// boundary, or of exactly maxLength characters if the first word is longer.
+func cutSpeechInput(text string, maxLength int) string {
+	cut, lastSpace := len(text), -1
+	count := 0
+	for i, r := range text {
+		if count == maxLength {
+			cut = i
+			break
+		}
+		if unicode.IsSpace(r) && i > 0 {
+			lastSpace = i
+		}
+		count++
+	}
+	if next, _ := utf8.DecodeRuneInString(text[cut:]); cut < len(text) && lastSpace > 0 && !unicode.IsSpace(next) {
+		return text[:lastSpace]
+	}
+	return text[:cut]
+}
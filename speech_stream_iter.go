@@ -0,0 +1,32 @@
+//go:build go1.23
+
+package openai
+
+import (
+	"errors"
+	"io"
+	"iter"
+)
+
+// All returns an iterator over the remaining chunks of the stream. A non-nil error is yielded once,
+// as the last element, when receiving the audio fails. The stream is not closed.
+//
+//	for chunk, err := range stream.All() {
+//		if err != nil {
+//			...
+//		}
+//		play(chunk.Samples())
+//	}
+func (s *SpeechStream) All() iter.Seq2[SpeechChunk, error] {
+	return func(yield func(SpeechChunk, error) bool) {
+		for {
+			chunk, err := s.Recv()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if !yield(chunk, err) || err != nil {
+				return
+			}
+		}
+	}
+}
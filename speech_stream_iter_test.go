@@ -0,0 +1,42 @@
+//go:build go1.23
+
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/neospace-ai/go-openai"
+	"github.com/neospace-ai/go-openai/internal/test/checks"
+)
+
+func TestSpeechStreamAll(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	speech := &speechServer{}
+	speech.register(t, server)
+
+	request := openai.CreateSpeechRequest{Model: openai.TTSModel1, Voice: openai.VoiceAlloy}
+	request.Input = strings.Repeat("Fine. ", 700) + "FAIL."
+	stream, err := client.CreateSpeechStream(context.Background(), request)
+	checks.NoError(t, err, "CreateSpeechStream error")
+	defer stream.Close()
+
+	var (
+		audio   []byte
+		lastErr error
+	)
+	for chunk, err := range stream.All() {
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		audio = append(audio, chunk.Data...)
+	}
+	var apiErr *openai.APIError
+	if !errors.As(lastErr, &apiErr) || len(audio) != len(strings.TrimSpace(strings.Repeat("Fine. ", 682))) {
+		t.Fatalf("got %d bytes of audio and error %v", len(audio), lastErr)
+	}
+}
@@ -0,0 +1,253 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/neospace-ai/go-openai"
+	"github.com/neospace-ai/go-openai/internal/test"
+	"github.com/neospace-ai/go-openai/internal/test/checks"
+)
+
+// speechServer speaks an input as its bytes, wrapped in a WAV file for the wav response format,
+// written a few bytes at a time. Inputs containing "FAIL" fail. WAV files are streamed without
+// their size, unless trailer is set, which is then appended to them.
+type speechServer struct {
+	mu      sync.Mutex
+	inputs  []string
+	trailer string
+}
+
+func (s *speechServer) register(t *testing.T, server *test.ServerTest) {
+	server.RegisterHandler("/v1/audio/speech", func(w http.ResponseWriter, r *http.Request) {
+		var request openai.CreateSpeechRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		s.mu.Lock()
+		s.inputs = append(s.inputs, request.Input)
+		s.mu.Unlock()
+
+		if strings.Contains(request.Input, "FAIL") {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"error":{"message":"speech failed","type":"server_error"}}`)
+			return
+		}
+
+		audio := []byte(request.Input)
+		if request.ResponseFormat == openai.SpeechResponseFormatWav {
+			audio = testWAV(audio[:len(audio)-len(audio)%2])
+			if s.trailer != "" {
+				audio = append(audio, s.trailer...)
+			} else {
+				// Streamed WAV files do not know their size.
+				binary.LittleEndian.PutUint32(audio[4:], 0xFFFFFFFF)
+				binary.LittleEndian.PutUint32(audio[52:], 0xFFFFFFFF)
+			}
+		}
+		for len(audio) > 0 {
+			n := min(7, len(audio))
+			_, _ = w.Write(audio[:n])
+			w.(http.Flusher).Flush()
+			audio = audio[n:]
+		}
+	})
+}
+
+func (s *speechServer) requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.inputs...)
+}
+
+func receiveSpeech(t *testing.T, stream *openai.SpeechStream) ([]openai.SpeechChunk, []byte, error) {
+	t.Helper()
+	var (
+		chunks []openai.SpeechChunk
+		audio  []byte
+	)
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return chunks, audio, nil
+		}
+		if err != nil {
+			return chunks, audio, err
+		}
+		chunks = append(chunks, chunk)
+		audio = append(audio, chunk.Data...)
+	}
+}
+
+func TestSpeechStreamSplitsInput(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	speech := &speechServer{}
+	speech.register(t, server)
+
+	var sentences []string
+	for i := 0; len(strings.Join(sentences, " ")) < 9000; i++ {
+		sentences = append(sentences, fmt.Sprintf("Sentence number %d is spoken (and \"quoted\").", i))
+	}
+	input := strings.Join(sentences, " ")
+
+	stream, err := client.CreateSpeechStream(context.Background(), openai.CreateSpeechRequest{
+		Model:          openai.TTSModel1,
+		Input:          input,
+		Voice:          openai.VoiceAlloy,
+		ResponseFormat: openai.SpeechResponseFormatPcm,
+	})
+	checks.NoError(t, err, "CreateSpeechStream error")
+	defer stream.Close()
+
+	chunks, audio, err := receiveSpeech(t, stream)
+	checks.NoError(t, err, "Recv error")
+
+	parts := speech.requests()
+	if len(parts) != 3 || strings.Join(parts, " ") != input {
+		t.Fatalf("unexpected parts of %d characters: %d", len(input), len(parts))
+	}
+	var want []byte
+	for _, part := range parts {
+		if utf8.RuneCountInString(part) > 4096 || !strings.HasSuffix(part, `").`) {
+			t.Errorf("part of %d characters ending with %q", len(part), part[len(part)-10:])
+		}
+		// A partial frame at the end of a part is dropped.
+		want = append(want, part[:len(part)-len(part)%2]...)
+	}
+	if string(audio) != string(want) {
+		t.Fatalf("got %d bytes of audio, want %d", len(audio), len(want))
+	}
+
+	format := openai.PCMFormat{SampleRate: 24000, Channels: 1, BitsPerSample: 16}
+	for _, chunk := range chunks {
+		if chunk.Format != format || len(chunk.Data)%2 != 0 || len(chunk.Samples()) != len(chunk.Data)/2 {
+			t.Fatalf("unexpected chunk of part %d: %d bytes of %+v", chunk.Part, len(chunk.Data), chunk.Format)
+		}
+	}
+	if chunks[len(chunks)-1].Part != 2 {
+		t.Errorf("last chunk is of part %d", chunks[len(chunks)-1].Part)
+	}
+}
+
+func TestSpeechStreamSplitsLongSentences(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	speech := &speechServer{}
+	speech.register(t, server)
+
+	input := strings.Repeat("word ", 500) + strings.Repeat("é", 5000)
+	stream, err := client.CreateSpeechStream(context.Background(), openai.CreateSpeechRequest{
+		Model: openai.TTSModel1,
+		Input: input,
+		Voice: openai.VoiceAlloy,
+	})
+	checks.NoError(t, err, "CreateSpeechStream error")
+	defer stream.Close()
+
+	chunks, audio, err := receiveSpeech(t, stream)
+	checks.NoError(t, err, "Recv error")
+
+	parts := speech.requests()
+	want := []string{
+		strings.TrimSpace(strings.Repeat("word ", 500)),
+		strings.Repeat("é", 4096),
+		strings.Repeat("é", 904),
+	}
+	if strings.Join(parts, "|") != strings.Join(want, "|") {
+		t.Fatalf("unexpected parts of %d characters", len(parts))
+	}
+	// Encoded audio is passed through.
+	if string(audio) != strings.Join(parts, "") || chunks[0].Format != (openai.PCMFormat{}) || chunks[0].Samples() != nil {
+		t.Fatalf("unexpected audio of %d bytes", len(audio))
+	}
+}
+
+func TestSpeechStreamWAV(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	speech := &speechServer{}
+	speech.register(t, server)
+
+	stream, err := client.CreateSpeechStream(context.Background(), openai.CreateSpeechRequest{
+		Model:          openai.TTSModel1,
+		Input:          "\x00\x40\x00\x30",
+		Voice:          openai.VoiceAlloy,
+		ResponseFormat: openai.SpeechResponseFormatWav,
+	})
+	checks.NoError(t, err, "CreateSpeechStream error")
+	defer stream.Close()
+
+	chunks, audio, err := receiveSpeech(t, stream)
+	checks.NoError(t, err, "Recv error")
+	if string(audio) != "\x00\x40\x00\x30" {
+		t.Fatalf("unexpected audio %q", audio)
+	}
+	var samples []float32
+	for _, chunk := range chunks {
+		if chunk.Format != (openai.PCMFormat{SampleRate: 8000, Channels: 1, BitsPerSample: 16}) {
+			t.Fatalf("unexpected format %+v", chunk.Format)
+		}
+		samples = append(samples, chunk.Samples()...)
+	}
+	if len(samples) != 2 || samples[0] != 0.5 || samples[1] != 0.375 {
+		t.Fatalf("unexpected samples %v", samples)
+	}
+
+	// The chunks following a data chunk of a known size are not audio.
+	speech.trailer = "LIST\x06\x00\x00\x00INFOab"
+	stream, err = client.CreateSpeechStream(context.Background(), openai.CreateSpeechRequest{
+		Model:          openai.TTSModel1,
+		Input:          "\x00\x40\x00\x30",
+		Voice:          openai.VoiceAlloy,
+		ResponseFormat: openai.SpeechResponseFormatWav,
+	})
+	checks.NoError(t, err, "CreateSpeechStream error")
+	defer stream.Close()
+	_, audio, err = receiveSpeech(t, stream)
+	checks.NoError(t, err, "Recv error")
+	if string(audio) != "\x00\x40\x00\x30" {
+		t.Fatalf("unexpected audio %q with a trailing chunk", audio)
+	}
+}
+
+func TestSpeechStreamErrors(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	speech := &speechServer{}
+	speech.register(t, server)
+
+	request := openai.CreateSpeechRequest{Model: openai.TTSModel1, Voice: openai.VoiceAlloy, Input: "FAIL."}
+	_, err := client.CreateSpeechStream(context.Background(), request)
+	checks.HasError(t, err, "the first part fails")
+
+	request.Input = strings.Repeat("Fine. ", 700) + "FAIL."
+	stream, err := client.CreateSpeechStream(context.Background(), request)
+	checks.NoError(t, err, "CreateSpeechStream error")
+	_, audio, err := receiveSpeech(t, stream)
+	var apiErr *openai.APIError
+	if !errors.As(err, &apiErr) || string(audio) != strings.TrimSpace(strings.Repeat("Fine. ", 682)) {
+		t.Fatalf("got %d bytes of audio and error %v", len(audio), err)
+	}
+	checks.NoError(t, stream.Close(), "Close error")
+
+	// Closing the stream early releases the request of the next part.
+	request.Input = strings.Repeat("Fine. ", 2000)
+	stream, err = client.CreateSpeechStream(context.Background(), request)
+	checks.NoError(t, err, "CreateSpeechStream error")
+	_, err = stream.Recv()
+	checks.NoError(t, err, "Recv error")
+	checks.NoError(t, stream.Close(), "Close error")
+	if _, err = stream.Recv(); !errors.Is(err, io.EOF) {
+		t.Fatalf("Recv after Close: %v", err)
+	}
+}
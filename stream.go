@@ -7,6 +7,9 @@ import (
 
 var (
 	ErrTooManyEmptyStreamMessages = errors.New("stream has sent too many empty messages")
+	ErrStreamTruncated            = errors.New("stream ended before the [DONE] message")
+	ErrStreamNotResumable         = errors.New("stream cannot be resumed, no event id was received")
+	ErrStreamResumeUnsupported    = errors.New("stream resume strategy is not supported")
 )
 
 type CompletionStream struct {
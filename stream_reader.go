@@ -3,25 +3,57 @@ package openai
 import (
 	"bufio"
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"time"
 
 	utils "github.com/neospace-ai/go-openai/internal"
 )
 
 var (
-	headerData  = []byte("data: ")
-	errorPrefix = []byte(`data: {"error":`)
+	errorPrefix = []byte(`{"error":`)
+	doneData    = []byte("[DONE]")
 )
 
+// Server-sent event field names.
+// https://html.spec.whatwg.org/multipage/server-sent-events.html#event-stream-interpretation
+const (
+	sseFieldData  = "data"
+	sseFieldEvent = "event"
+	sseFieldID    = "id"
+	sseFieldRetry = "retry"
+)
+
+// StreamEvent is a single dispatched server-sent event.
+type StreamEvent struct {
+	// Event is the event type set by the "event:" field. An empty value means "message".
+	Event string
+	// ID is the last event ID seen on the stream when this event was dispatched.
+	ID string
+	// Data is the concatenation of all "data:" lines of the event, joined by newlines.
+	Data []byte
+}
+
 type streamable interface {
-	ChatCompletionStreamResponse | CompletionResponse
+	ChatCompletionStreamResponse | CompletionResponse | AssistantStreamEvent
+}
+
+// streamEventUnmarshaler is implemented by stream responses whose payload type
+// depends on the name of the event carrying it.
+type streamEventUnmarshaler interface {
+	unmarshalStreamEvent(event StreamEvent, unmarshaler utils.Unmarshaler) error
 }
 
 type streamReader[T streamable] struct {
 	emptyMessagesLimit uint
 	isFinished         bool
+	lastEventID        string
+	retry              time.Duration
+	// detectTruncation makes read errors before the [DONE] message wrap ErrStreamTruncated.
+	detectTruncation bool
 
 	reader         *bufio.Reader
 	response       *http.Response
@@ -41,57 +73,166 @@ func (stream *streamReader[T]) Recv() (response T, err error) {
 	return
 }
 
-//nolint:gocognit
+// RecvEvent returns the next raw server-sent event without decoding its data.
+// It returns io.EOF once the stream sent its [DONE] message.
+func (stream *streamReader[T]) RecvEvent() (event StreamEvent, err error) {
+	if stream.isFinished {
+		err = io.EOF
+		return
+	}
+
+	event, err = stream.nextEvent()
+	if err != nil {
+		return
+	}
+	if bytes.Equal(event.Data, doneData) {
+		stream.isFinished = true
+		return StreamEvent{}, io.EOF
+	}
+	return
+}
+
+// LastEventID returns the ID of the last event received, as set by the "id:" field.
+func (stream *streamReader[T]) LastEventID() string {
+	return stream.lastEventID
+}
+
+// RetryInterval returns the reconnection time requested by the server through the
+// "retry:" field, or zero if none was sent.
+func (stream *streamReader[T]) RetryInterval() time.Duration {
+	return stream.retry
+}
+
 func (stream *streamReader[T]) processLines() (T, error) {
+	event, err := stream.nextEvent()
+	if err != nil {
+		return *new(T), err
+	}
+
+	if bytes.Equal(event.Data, doneData) {
+		stream.isFinished = true
+		return *new(T), io.EOF
+	}
+
+	var (
+		response     T
+		unmarshalErr error
+	)
+	if eventUnmarshaler, ok := any(&response).(streamEventUnmarshaler); ok {
+		unmarshalErr = eventUnmarshaler.unmarshalStreamEvent(event, stream.unmarshaler)
+	} else {
+		unmarshalErr = stream.unmarshaler.Unmarshal(event.Data, &response)
+	}
+	if unmarshalErr != nil {
+		return *new(T), unmarshalErr
+	}
+
+	return response, nil
+}
+
+// nextEvent reads lines until an event carrying data is dispatched.
+//
+// Comments and the event, id and retry fields are handled as described by the
+// SSE specification. Blank lines that dispatch no data and lines that are not
+// SSE fields count toward the empty messages limit, and the latter are collected
+// in the error accumulator because some servers send plain JSON errors instead
+// of events. An event whose data is an error object is returned as an error.
+//
+//nolint:gocognit
+func (stream *streamReader[T]) nextEvent() (StreamEvent, error) {
 	var (
 		emptyMessagesCount uint
-		hasErrorPrefix     bool
+		eventType          string
+		data               []byte
+		hasData            bool
 	)
 
 	for {
 		rawLine, readErr := stream.reader.ReadBytes('\n')
-		if readErr != nil || hasErrorPrefix {
+		if readErr != nil {
 			respErr := stream.unmarshalError()
 			if respErr != nil {
-				return *new(T), fmt.Errorf("error, %w", respErr.Error)
+				return StreamEvent{}, fmt.Errorf("error, %w", respErr.Error)
 			}
-			return *new(T), readErr
+			if stream.detectTruncation {
+				// A bare EOF must not be mistaken for the normal end of the stream.
+				if errors.Is(readErr, io.EOF) {
+					return StreamEvent{}, ErrStreamTruncated
+				}
+				return StreamEvent{}, fmt.Errorf("%w: %w", ErrStreamTruncated, readErr)
+			}
+			return StreamEvent{}, readErr
 		}
 
-		noSpaceLine := bytes.TrimSpace(rawLine)
-		if bytes.HasPrefix(noSpaceLine, errorPrefix) {
-			hasErrorPrefix = true
-		}
-		if !bytes.HasPrefix(noSpaceLine, headerData) || hasErrorPrefix {
-			if hasErrorPrefix {
-				noSpaceLine = bytes.TrimPrefix(noSpaceLine, headerData)
+		line := bytes.TrimRight(rawLine, "\r\n")
+		if len(line) == 0 && hasData {
+			if bytes.HasPrefix(data, errorPrefix) {
+				writeErr := stream.errAccumulator.Write(data)
+				if writeErr != nil {
+					return StreamEvent{}, writeErr
+				}
+				if respErr := stream.unmarshalError(); respErr != nil {
+					return StreamEvent{}, fmt.Errorf("error, %w", respErr.Error)
+				}
 			}
-			writeErr := stream.errAccumulator.Write(noSpaceLine)
+			return StreamEvent{
+				Event: eventType,
+				ID:    stream.lastEventID,
+				Data:  data,
+			}, nil
+		}
+		if len(line) > 0 && line[0] == ':' {
+			// Comment, usually a keep-alive heartbeat.
+			continue
+		}
+
+		field, value, known := parseStreamField(line)
+		switch {
+		case !known:
+			// Blank line without data or a line that is not an SSE field.
+			writeErr := stream.errAccumulator.Write(bytes.TrimSpace(line))
 			if writeErr != nil {
-				return *new(T), writeErr
+				return StreamEvent{}, writeErr
 			}
 			emptyMessagesCount++
 			if emptyMessagesCount > stream.emptyMessagesLimit {
-				return *new(T), ErrTooManyEmptyStreamMessages
+				return StreamEvent{}, ErrTooManyEmptyStreamMessages
+			}
+			eventType = ""
+		case field == sseFieldData:
+			if hasData {
+				data = append(data, '\n')
+			}
+			data = append(data, value...)
+			hasData = true
+		case field == sseFieldEvent:
+			eventType = string(value)
+		case field == sseFieldID:
+			if bytes.IndexByte(value, 0) < 0 {
+				stream.lastEventID = string(value)
+			}
+		case field == sseFieldRetry:
+			if ms, err := strconv.ParseUint(string(value), 10, 63); err == nil {
+				stream.retry = time.Duration(ms) * time.Millisecond
 			}
-
-			continue
-		}
-
-		noPrefixLine := bytes.TrimPrefix(noSpaceLine, headerData)
-		if string(noPrefixLine) == "[DONE]" {
-			stream.isFinished = true
-			return *new(T), io.EOF
-		}
-
-		var response T
-		unmarshalErr := stream.unmarshaler.Unmarshal(noPrefixLine, &response)
-		if unmarshalErr != nil {
-			return *new(T), unmarshalErr
 		}
+	}
+}
 
-		return response, nil
+// parseStreamField splits an event stream line into its field name and value,
+// removing the single optional space after the colon. It reports whether the
+// field is one the SSE specification defines.
+func parseStreamField(line []byte) (field string, value []byte, known bool) {
+	name, value, found := bytes.Cut(line, []byte(":"))
+	if found && len(value) > 0 && value[0] == ' ' {
+		value = value[1:]
+	}
+	field = string(name)
+	switch field {
+	case sseFieldData, sseFieldEvent, sseFieldID, sseFieldRetry:
+		return field, value, true
 	}
+	return field, value, false
 }
 
 func (stream *streamReader[T]) unmarshalError() (errResp *ErrorResponse) {
@@ -4,7 +4,9 @@ import (
 	"bufio"
 	"bytes"
 	"errors"
+	"io"
 	"testing"
+	"time"
 
 	utils "github.com/neospace-ai/go-openai/internal"
 	"github.com/neospace-ai/go-openai/internal/test"
@@ -63,3 +65,59 @@ func TestStreamReaderReturnsErrTestErrorAccumulatorWriteFailed(t *testing.T) {
 	_, err := stream.Recv()
 	checks.ErrorIs(t, err, test.ErrTestErrorAccumulatorWriteFailed, "Did not return error when write failed", err.Error())
 }
+
+func TestStreamReaderParsesEventStreamFields(t *testing.T) {
+	input := ": heartbeat\n" +
+		"retry: 1500\n" +
+		"id: evt_1\n" +
+		"event: thread.message.delta\n" +
+		"data: {\"a\":\n" +
+		"data:1}\n" +
+		"\n" +
+		": another heartbeat\n" +
+		"event: done\n" +
+		"data: [DONE]\n\n"
+	stream := &streamReader[ChatCompletionStreamResponse]{
+		emptyMessagesLimit: 1,
+		reader:             bufio.NewReader(bytes.NewReader([]byte(input))),
+		errAccumulator:     utils.NewErrorAccumulator(),
+		unmarshaler:        &utils.JSONUnmarshaler{},
+	}
+
+	event, err := stream.RecvEvent()
+	checks.NoError(t, err, "RecvEvent failed")
+	if event.Event != "thread.message.delta" {
+		t.Errorf("unexpected event type %q", event.Event)
+	}
+	if event.ID != "evt_1" || stream.LastEventID() != "evt_1" {
+		t.Errorf("unexpected event id %q", event.ID)
+	}
+	if string(event.Data) != "{\"a\":\n1}" {
+		t.Errorf("unexpected event data %q", event.Data)
+	}
+	if stream.RetryInterval() != 1500*time.Millisecond {
+		t.Errorf("unexpected retry interval %v", stream.RetryInterval())
+	}
+
+	_, err = stream.RecvEvent()
+	checks.ErrorIs(t, err, io.EOF, "RecvEvent did not return EOF after [DONE]")
+}
+
+func TestStreamReaderSkipsHeartbeatsAndCRLF(t *testing.T) {
+	input := ":\r\n: ping\r\n: ping\r\n" +
+		"data: {\"id\":\"1\"}\r\n\r\n" +
+		"data: [DONE]\r\n\r\n"
+	stream := &streamReader[ChatCompletionStreamResponse]{
+		reader:         bufio.NewReader(bytes.NewReader([]byte(input))),
+		errAccumulator: utils.NewErrorAccumulator(),
+		unmarshaler:    &utils.JSONUnmarshaler{},
+	}
+
+	response, err := stream.Recv()
+	checks.NoError(t, err, "Recv failed")
+	if response.ID != "1" {
+		t.Errorf("unexpected response id %q", response.ID)
+	}
+	_, err = stream.Recv()
+	checks.ErrorIs(t, err, io.EOF, "Recv did not return EOF after [DONE]")
+}
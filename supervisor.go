@@ -97,7 +97,14 @@ func TransformTaskToSupervisorMechanics(task Task) supervisorMechanics {
 }
 
 func (req SupervisorRequest) ToNeolangInput() any {
+	input, err := req.neolangInput()
+	if err != nil {
+		panic(err.Error())
+	}
+	return input
+}
 
+func (req SupervisorRequest) neolangInput() (neolangInput, error) {
 	messages := make([]map[string]any, len(req.History)+1)
 
 	for idx, msg := range req.History {
@@ -128,7 +135,7 @@ func (req SupervisorRequest) ToNeolangInput() any {
 		SupervisorMechanics: TransformTaskToSupervisorMechanics(req.Task),
 	})
 	if err != nil {
-		panic(fmt.Sprintf("failed to marshal prompt: %v", err))
+		return neolangInput{}, fmt.Errorf("failed to marshal prompt: %w", err)
 	}
 
 	input := neolangInput{
@@ -137,7 +144,7 @@ func (req SupervisorRequest) ToNeolangInput() any {
 		Temperature: req.Temperature,
 		Prompt:      string(promptStr),
 	}
-	return input
+	return input, nil
 }
 
 func setChosenName(response *SupervisorResponse) {
@@ -37,3 +37,7 @@ type SupervisorScore struct {
 	Perfect     bool    `json:"perfect" bson:"perfect"`
 	Weight      float64 `json:"weight" bson:"weight"`
 }
+
+func (t *TaskResultCollection) isEmpty() bool {
+	return t.RawResponse == "" && t.TaskGuard == nil && t.TaskSelectExpertise == nil
+}
@@ -75,6 +75,18 @@ const (
 	ChunkingStrategyTypeStatic ChunkingStrategyType = "static"
 )
 
+// NewStaticChunkingStrategy returns a strategy splitting files into chunks of at most maxChunkSizeTokens
+// tokens, consecutive chunks sharing chunkOverlapTokens tokens.
+func NewStaticChunkingStrategy(maxChunkSizeTokens, chunkOverlapTokens int) *ChunkingStrategy {
+	return &ChunkingStrategy{
+		Type: ChunkingStrategyTypeStatic,
+		Static: &StaticChunkingStrategy{
+			MaxChunkSizeTokens: maxChunkSizeTokens,
+			ChunkOverlapTokens: chunkOverlapTokens,
+		},
+	}
+}
+
 type ModifyThreadRequest struct {
 	Metadata      map[string]any `json:"metadata"`
 	ToolResources *ToolResources `json:"tool_resources,omitempty"`
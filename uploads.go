@@ -0,0 +1,113 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	utils "github.com/neospace-ai/go-openai/internal"
+)
+
+const uploadsSuffix = "/uploads"
+
+type UploadStatus string
+
+const (
+	UploadStatusPending   UploadStatus = "pending"
+	UploadStatusCompleted UploadStatus = "completed"
+	UploadStatusCancelled UploadStatus = "cancelled"
+	UploadStatusExpired   UploadStatus = "expired"
+)
+
+// CreateUploadRequest represents a request to create an upload, which parts are then added to.
+type CreateUploadRequest struct {
+	Filename string      `json:"filename"`
+	Purpose  PurposeType `json:"purpose"`
+	// Bytes is the total size of the file. The parts added to the upload must add up to it.
+	Bytes    int64  `json:"bytes"`
+	MimeType string `json:"mime_type"`
+}
+
+// Upload is a file being uploaded in parts. File is set once the upload is completed.
+type Upload struct {
+	ID        string       `json:"id"`
+	Object    string       `json:"object"`
+	Bytes     int64        `json:"bytes"`
+	CreatedAt int64        `json:"created_at"`
+	Filename  string       `json:"filename"`
+	Purpose   string       `json:"purpose"`
+	Status    UploadStatus `json:"status"`
+	ExpiresAt int64        `json:"expires_at"`
+	File      *File        `json:"file"`
+
+	httpHeader
+}
+
+// UploadPart is a part added to an upload.
+type UploadPart struct {
+	ID        string `json:"id"`
+	Object    string `json:"object"`
+	CreatedAt int64  `json:"created_at"`
+	UploadID  string `json:"upload_id"`
+
+	httpHeader
+}
+
+// CompleteUploadRequest lists the parts of an upload, in the order they are assembled in.
+type CompleteUploadRequest struct {
+	PartIDs []string `json:"part_ids"`
+	// MD5 is an optional checksum of the file, verified against the assembled parts.
+	MD5 string `json:"md5,omitempty"`
+}
+
+// CreateUpload creates an upload that parts can be added to for an hour.
+func (c *Client) CreateUpload(ctx context.Context, request CreateUploadRequest) (response Upload, err error) {
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(uploadsSuffix), withBody(request))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// AddUploadPart adds a part of at most 64 MB to an upload. Parts can be added concurrently.
+func (c *Client) AddUploadPart(ctx context.Context, uploadID string, data io.Reader) (response UploadPart, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s/parts", uploadsSuffix, uploadID)
+	err = c.sendMultipartRequest(ctx, c.fullURL(urlSuffix), nil, func(builder utils.FormBuilder) error {
+		if err := builder.CreateFormFileReader("data", data, "data"); err != nil {
+			return err
+		}
+		return builder.Close()
+	}, &response)
+	return
+}
+
+// CompleteUpload assembles the parts of an upload into a file.
+func (c *Client) CompleteUpload(
+	ctx context.Context,
+	uploadID string,
+	request CompleteUploadRequest,
+) (response Upload, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s/complete", uploadsSuffix, uploadID)
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix), withBody(request))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// CancelUpload cancels an upload. No parts can be added to it afterwards.
+func (c *Client) CancelUpload(ctx context.Context, uploadID string) (response Upload, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s/cancel", uploadsSuffix, uploadID)
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
@@ -0,0 +1,148 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/neospace-ai/go-openai"
+	"github.com/neospace-ai/go-openai/internal/test"
+	"github.com/neospace-ai/go-openai/internal/test/checks"
+)
+
+// uploadServer emulates the Uploads API, failing the first attempt of every part with failStatus.
+type uploadServer struct {
+	mu         sync.Mutex
+	request    openai.CreateUploadRequest
+	parts      map[string]string
+	failed     map[string]bool
+	failStatus int
+	cancelled  atomic.Bool
+}
+
+func (s *uploadServer) register(t *testing.T, server *test.ServerTest) {
+	server.RegisterHandler("/v1/uploads", func(w http.ResponseWriter, r *http.Request) {
+		checks.NoError(t, json.NewDecoder(r.Body).Decode(&s.request), "Decode error")
+		fmt.Fprint(w, `{"id":"upload_1","object":"upload","status":"pending"}`)
+	})
+	server.RegisterHandler("/v1/uploads/upload_1/*", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/parts"):
+			s.handlePart(t, w, r)
+		case strings.HasSuffix(r.URL.Path, "/complete"):
+			var request openai.CompleteUploadRequest
+			checks.NoError(t, json.NewDecoder(r.Body).Decode(&request), "Decode error")
+			var content strings.Builder
+			s.mu.Lock()
+			for _, id := range request.PartIDs {
+				content.WriteString(s.parts[id])
+			}
+			s.mu.Unlock()
+			fmt.Fprintf(w, `{"id":"upload_1","status":"completed","file":{"id":"file_1","bytes":%d,"filename":%q}}`,
+				content.Len(), content.String())
+		case strings.HasSuffix(r.URL.Path, "/cancel"):
+			s.cancelled.Store(true)
+			fmt.Fprint(w, `{"id":"upload_1","status":"cancelled"}`)
+		}
+	})
+}
+
+func (s *uploadServer) handlePart(t *testing.T, w http.ResponseWriter, r *http.Request) {
+	file, _, err := r.FormFile("data")
+	checks.NoError(t, err, "FormFile error")
+	data, err := io.ReadAll(file)
+	checks.NoError(t, err, "ReadAll error")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failStatus != 0 && !s.failed[string(data)] {
+		s.failed[string(data)] = true
+		w.WriteHeader(s.failStatus)
+		fmt.Fprint(w, `{"error":{"message":"part failed"}}`)
+		return
+	}
+	id := fmt.Sprintf("part_%d", len(s.parts))
+	s.parts[id] = string(data)
+	fmt.Fprintf(w, `{"id":%q,"object":"upload.part","upload_id":"upload_1"}`, id)
+}
+
+func newUploadServer(failStatus int) *uploadServer {
+	return &uploadServer{
+		parts:      make(map[string]string),
+		failed:     make(map[string]bool),
+		failStatus: failStatus,
+	}
+}
+
+func TestChunkedUploader(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	uploads := newUploadServer(http.StatusInternalServerError)
+	uploads.register(t, server)
+
+	var progress []int64
+	uploader := openai.NewChunkedUploader(client)
+	uploader.PartSize = 10
+	uploader.RetryInterval = time.Millisecond
+	uploader.OnProgress = func(uploaded, total int64) {
+		progress = append(progress, uploaded)
+		if total != 95 {
+			t.Errorf("OnProgress total = %d, want 95", total)
+		}
+	}
+
+	content := strings.Repeat("0123456789abcdefghi", 5)
+	file, err := uploader.Upload(context.Background(), openai.CreateUploadRequest{
+		Filename: "batch.jsonl",
+		Purpose:  openai.PurposeBatch,
+	}, strings.NewReader(content))
+	checks.NoError(t, err, "Upload error")
+
+	if file.ID != "file_1" || file.FileName != content {
+		t.Errorf("parts were not assembled in order: %+v", file)
+	}
+	if uploads.request.Bytes != 95 || uploads.request.MimeType != "text/jsonl" {
+		t.Errorf("unexpected create upload request: %+v", uploads.request)
+	}
+	if len(progress) != 10 || progress[9] != 95 {
+		t.Errorf("unexpected progress: %v", progress)
+	}
+}
+
+func TestChunkedUploaderCancelsFailedUpload(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	uploads := newUploadServer(http.StatusBadRequest)
+	uploads.register(t, server)
+
+	uploader := openai.NewChunkedUploader(client)
+	uploader.PartSize = 10
+	_, err := uploader.Upload(context.Background(), openai.CreateUploadRequest{
+		Filename: "batch.jsonl",
+		Purpose:  openai.PurposeBatch,
+	}, strings.NewReader(strings.Repeat("x", 25)))
+
+	var apiErr *openai.APIError
+	if !errors.As(err, &apiErr) || apiErr.HTTPStatusCode != http.StatusBadRequest {
+		t.Fatalf("Upload should return the part error without retrying it, got %v", err)
+	}
+	if !uploads.cancelled.Load() {
+		t.Error("Upload should cancel the upload when a part fails")
+	}
+}
+
+func TestChunkedUploaderUnknownSize(t *testing.T) {
+	client := openai.NewClient("")
+	_, err := openai.NewChunkedUploader(client).Upload(context.Background(), openai.CreateUploadRequest{
+		Filename: "batch.jsonl",
+	}, io.MultiReader(strings.NewReader("data")))
+	checks.ErrorIs(t, err, openai.ErrUploadSizeUnknown, "Upload should require the size of arbitrary readers")
+}
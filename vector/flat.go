@@ -0,0 +1,141 @@
+package vector
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"sync"
+
+	"github.com/neospace-ai/go-openai"
+)
+
+type entry struct {
+	id       string
+	vector   []float32
+	metadata Metadata
+}
+
+// FlatIndex is an Index that compares the query to every vector. Its results are exact, and it is
+// fast enough for tens of thousands of vectors.
+type FlatIndex struct {
+	metric     Metric
+	dimensions int
+
+	mu      sync.RWMutex
+	entries []entry
+	ids     map[string]int
+}
+
+// NewFlatIndex returns an empty flat index ranking vectors by the given metric.
+func NewFlatIndex(metric Metric) *FlatIndex {
+	return &FlatIndex{metric: metric, ids: make(map[string]int)}
+}
+
+func (f *FlatIndex) Add(id string, vector []float32, metadata Metadata) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	dimensions, err := checkDimensions(f.dimensions, len(vector))
+	if err != nil {
+		return err
+	}
+	f.dimensions = dimensions
+
+	e := entry{id: id, vector: f.metric.prepare(vector), metadata: maps.Clone(metadata)}
+	if i, ok := f.ids[id]; ok {
+		f.entries[i] = e
+		return nil
+	}
+	f.ids[id] = len(f.entries)
+	f.entries = append(f.entries, e)
+	return nil
+}
+
+func (f *FlatIndex) Delete(id string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i, ok := f.ids[id]
+	if !ok {
+		return false
+	}
+	delete(f.ids, id)
+	last := len(f.entries) - 1
+	if i != last {
+		f.entries[i] = f.entries[last]
+		f.ids[f.entries[i].id] = i
+	}
+	f.entries[last] = entry{}
+	f.entries = f.entries[:last]
+	return true
+}
+
+func (f *FlatIndex) Search(query []float32, k int, filter Filter) ([]Result, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if len(f.entries) == 0 || k <= 0 {
+		return nil, nil
+	}
+	if len(query) != f.dimensions {
+		return nil, openai.ErrVectorLengthMismatch
+	}
+
+	query = f.metric.prepare(query)
+	metric := f.metric.storage()
+	matches := make([]Match, 0, min(k, len(f.entries)))
+	for i := range f.entries {
+		if filter != nil && !filter(f.entries[i].metadata) {
+			continue
+		}
+		match := Match{Index: i, Score: metric.score(query, f.entries[i].vector, 0)}
+		matches = insertMatch(matches, 0, k, match, metric)
+	}
+
+	results := make([]Result, len(matches))
+	for i, match := range matches {
+		e := f.entries[match.Index]
+		results[i] = Result{ID: e.id, Metadata: e.metadata, Score: match.Score}
+	}
+	return results, nil
+}
+
+func (f *FlatIndex) Len() int {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return len(f.entries)
+}
+
+func (f *FlatIndex) Save(w io.Writer) error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	s := snapshot{
+		Version:    snapshotVersion,
+		Kind:       snapshotKindFlat,
+		Metric:     f.metric,
+		Dimensions: f.dimensions,
+		Entries:    make([]snapshotEntry, len(f.entries)),
+	}
+	for i, e := range f.entries {
+		s.Entries[i] = snapshotEntry{ID: e.id, Vector: e.vector, Metadata: e.metadata}
+	}
+	return s.write(w)
+}
+
+func loadFlatIndex(s *snapshot) (*FlatIndex, error) {
+	f := NewFlatIndex(s.Metric)
+	f.dimensions = s.Dimensions
+	f.entries = make([]entry, len(s.Entries))
+	for i, e := range s.Entries {
+		if len(e.Vector) != s.Dimensions {
+			return nil, ErrInvalidSnapshot
+		}
+		if _, ok := f.ids[e.ID]; ok {
+			return nil, fmt.Errorf("%w: duplicate id %q", ErrInvalidSnapshot, e.ID)
+		}
+		f.entries[i] = entry{id: e.ID, vector: e.Vector, metadata: e.Metadata}
+		f.ids[e.ID] = i
+	}
+	return f, nil
+}
@@ -0,0 +1,440 @@
+package vector
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/neospace-ai/go-openai"
+)
+
+const (
+	defaultHNSWM              = 16
+	defaultHNSWEfConstruction = 200
+	defaultHNSWEfSearch       = 64
+)
+
+var ErrInvalidHNSWSettings = errors.New("invalid HNSW settings")
+
+// HNSWIndex is an Index over a Hierarchical Navigable Small World graph, which finds approximate
+// nearest neighbors in logarithmic time. It suits indexes too large to scan for every search, at
+// the cost of missing a few of the nearest vectors.
+//
+// Deleted vectors are kept in the graph to route searches, but are never returned.
+type HNSWIndex struct {
+	// M is the number of neighbors of a vector on each layer of the graph, and twice as many on
+	// the bottom layer. It must be at least 2, or Add fails with ErrInvalidHNSWSettings, and may
+	// only be changed before the first Add.
+	M int
+	// EfConstruction is the number of candidate neighbors considered when adding a vector. It must be
+	// at least 1, or Add fails with ErrInvalidHNSWSettings, and may only be changed before the first Add.
+	EfConstruction int
+	// EfSearch is the number of candidates considered when searching, or k if greater. Greater
+	// values find more of the nearest vectors in longer searches.
+	EfSearch int
+
+	metric     Metric
+	dimensions int
+
+	mu         sync.RWMutex
+	nodes      []hnswNode
+	ids        map[string]int32
+	entryPoint int32
+	maxLevel   int
+	rng        *rand.Rand
+}
+
+type hnswNode struct {
+	entry
+	// neighbors are the neighbors of the node on each layer it is on, from the bottom one.
+	neighbors [][]int32
+	deleted   bool
+}
+
+// scored is a node along with its similarity to a query, higher for nearer nodes.
+type scored struct {
+	node       int32
+	similarity float32
+}
+
+// NewHNSWIndex returns an empty HNSW index ranking vectors by the given metric, with default settings.
+func NewHNSWIndex(metric Metric) *HNSWIndex {
+	return &HNSWIndex{
+		M:              defaultHNSWM,
+		EfConstruction: defaultHNSWEfConstruction,
+		EfSearch:       defaultHNSWEfSearch,
+		metric:         metric,
+		ids:            make(map[string]int32),
+		entryPoint:     -1,
+		rng:            rand.New(rand.NewSource(1)),
+	}
+}
+
+func (h *HNSWIndex) Add(id string, vector []float32, metadata Metadata) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.M < 2 {
+		return fmt.Errorf("%w: M is %d, not at least 2", ErrInvalidHNSWSettings, h.M)
+	}
+	if h.EfConstruction < 1 {
+		return fmt.Errorf("%w: EfConstruction is %d, not at least 1", ErrInvalidHNSWSettings, h.EfConstruction)
+	}
+	dimensions, err := checkDimensions(h.dimensions, len(vector))
+	if err != nil {
+		return err
+	}
+	h.dimensions = dimensions
+
+	if node, ok := h.ids[id]; ok {
+		h.update(node, h.metric.prepare(vector), maps.Clone(metadata))
+		return nil
+	}
+	level := int(math.Floor(-math.Log(1-h.rng.Float64()) / math.Log(float64(h.M))))
+	node := int32(len(h.nodes))
+	h.nodes = append(h.nodes, hnswNode{
+		entry:     entry{id: id, vector: h.metric.prepare(vector), metadata: maps.Clone(metadata)},
+		neighbors: make([][]int32, level+1),
+	})
+	h.ids[id] = node
+	h.insert(node, level)
+	return nil
+}
+
+// insert links a node into the graph, on every layer up to level.
+func (h *HNSWIndex) insert(node int32, level int) {
+	if h.entryPoint < 0 {
+		h.entryPoint = node
+		h.maxLevel = level
+		return
+	}
+
+	query := h.nodes[node].vector
+	nearest := []scored{{node: h.entryPoint, similarity: h.similarity(query, h.entryPoint)}}
+	for l := h.maxLevel; l > level; l-- {
+		nearest = h.searchLayer(query, nearest, 1, l, nil)
+	}
+	for l := min(level, h.maxLevel); l >= 0; l-- {
+		nearest = h.searchLayer(query, nearest, h.EfConstruction, l, nil)
+		neighbors := h.selectNeighbors(nearest, h.maxNeighbors(l))
+		h.nodes[node].neighbors[l] = neighbors
+		for _, neighbor := range neighbors {
+			h.connect(neighbor, node, l)
+		}
+	}
+
+	if level > h.maxLevel {
+		h.entryPoint = node
+		h.maxLevel = level
+	}
+}
+
+// update replaces the vector of a node in place and links it to its new nearest neighbors on the
+// layers it is on, so that adding an id again does not grow the graph. The nodes that linked to its
+// old vector keep their links, which still lead to the rest of the graph.
+func (h *HNSWIndex) update(node int32, vector []float32, metadata Metadata) {
+	h.nodes[node].vector = vector
+	h.nodes[node].metadata = metadata
+	if len(h.nodes) == 1 {
+		return
+	}
+
+	level := len(h.nodes[node].neighbors) - 1
+	other := func(n int32) bool { return n != node }
+	nearest := []scored{{node: h.entryPoint, similarity: h.similarity(vector, h.entryPoint)}}
+	for l := h.maxLevel; l >= 0; l-- {
+		ef := 1
+		if l <= level {
+			ef = h.EfConstruction
+		}
+		// The search starts from the node itself when it is the entry point, which it cannot return.
+		if found := h.searchLayer(vector, nearest, ef, l, other); len(found) > 0 {
+			nearest = found
+		}
+		if l > level {
+			continue
+		}
+		candidates := slices.DeleteFunc(slices.Clone(nearest), func(s scored) bool { return s.node == node })
+		neighbors := h.selectNeighbors(candidates, h.maxNeighbors(l))
+		h.nodes[node].neighbors[l] = neighbors
+		for _, neighbor := range neighbors {
+			if !slices.Contains(h.nodes[neighbor].neighbors[l], node) {
+				h.connect(neighbor, node, l)
+			}
+		}
+	}
+}
+
+// connect adds a neighbor to a node on a layer, pruning the neighbors of the node if it has too many.
+func (h *HNSWIndex) connect(node, neighbor int32, level int) {
+	neighbors := append(h.nodes[node].neighbors[level], neighbor)
+	if limit := h.maxNeighbors(level); len(neighbors) > limit {
+		vector := h.nodes[node].vector
+		candidates := make([]scored, len(neighbors))
+		for i, n := range neighbors {
+			candidates[i] = scored{node: n, similarity: h.similarity(vector, n)}
+		}
+		sort.Slice(candidates, func(i, j int) bool {
+			return candidates[i].similarity > candidates[j].similarity
+		})
+		neighbors = h.selectNeighbors(candidates, limit)
+	}
+	h.nodes[node].neighbors[level] = neighbors
+}
+
+func (h *HNSWIndex) maxNeighbors(level int) int {
+	if level == 0 {
+		return 2 * h.M
+	}
+	return h.M
+}
+
+// selectNeighbors picks up to m neighbors among candidates sorted best first. A candidate is preferred
+// when it is nearer to the base vector than to every neighbor already picked, which keeps links
+// towards every direction rather than into the nearest cluster only.
+func (h *HNSWIndex) selectNeighbors(candidates []scored, m int) []int32 {
+	selected := make([]int32, 0, m)
+	var pruned []int32
+	for _, candidate := range candidates {
+		if len(selected) == m {
+			break
+		}
+		vector := h.nodes[candidate.node].vector
+		diverse := true
+		for _, s := range selected {
+			if h.similarity(vector, s) > candidate.similarity {
+				diverse = false
+				break
+			}
+		}
+		if diverse {
+			selected = append(selected, candidate.node)
+		} else {
+			pruned = append(pruned, candidate.node)
+		}
+	}
+	for _, node := range pruned {
+		if len(selected) == m {
+			break
+		}
+		selected = append(selected, node)
+	}
+	return selected
+}
+
+// searchLayer returns up to ef nodes nearest to the query on a layer, best first, starting from
+// the entry points. Only the nodes accepted by accept are returned, but all nodes are followed.
+func (h *HNSWIndex) searchLayer(
+	query []float32,
+	entryPoints []scored,
+	ef, level int,
+	accept func(int32) bool,
+) []scored {
+	visited := make(map[int32]bool)
+	candidates := &bestFirst{}
+	results := &worstFirst{}
+	push := func(s scored) {
+		heap.Push(candidates, s)
+		if accept == nil || accept(s.node) {
+			heap.Push(results, s)
+			if results.Len() > ef {
+				heap.Pop(results)
+			}
+		}
+	}
+	for _, entryPoint := range entryPoints {
+		visited[entryPoint.node] = true
+		push(entryPoint)
+	}
+
+	for candidates.Len() > 0 {
+		candidate := heap.Pop(candidates).(scored)
+		if results.Len() >= ef && candidate.similarity < (*results)[0].similarity {
+			break
+		}
+		for _, neighbor := range h.nodes[candidate.node].neighbors[level] {
+			if visited[neighbor] {
+				continue
+			}
+			visited[neighbor] = true
+			similarity := h.similarity(query, neighbor)
+			if results.Len() < ef || similarity > (*results)[0].similarity {
+				push(scored{node: neighbor, similarity: similarity})
+			}
+		}
+	}
+
+	nearest := make([]scored, results.Len())
+	for i := len(nearest) - 1; i >= 0; i-- {
+		nearest[i] = heap.Pop(results).(scored)
+	}
+	return nearest
+}
+
+// similarity returns the similarity of a prepared query to a node, higher for nearer nodes.
+func (h *HNSWIndex) similarity(query []float32, node int32) float32 {
+	metric := h.metric.storage()
+	return metric.key(Match{Score: metric.score(query, h.nodes[node].vector, 0)})
+}
+
+func (h *HNSWIndex) Delete(id string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	node, ok := h.ids[id]
+	if !ok {
+		return false
+	}
+	delete(h.ids, id)
+	h.nodes[node].deleted = true
+	return true
+}
+
+func (h *HNSWIndex) Search(query []float32, k int, filter Filter) ([]Result, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if len(h.ids) == 0 || k <= 0 {
+		return nil, nil
+	}
+	if len(query) != h.dimensions {
+		return nil, openai.ErrVectorLengthMismatch
+	}
+
+	query = h.metric.prepare(query)
+	nearest := []scored{{node: h.entryPoint, similarity: h.similarity(query, h.entryPoint)}}
+	for l := h.maxLevel; l > 0; l-- {
+		nearest = h.searchLayer(query, nearest, 1, l, nil)
+	}
+	accept := func(node int32) bool {
+		n := &h.nodes[node]
+		return !n.deleted && (filter == nil || filter(n.metadata))
+	}
+	nearest = h.searchLayer(query, nearest, max(h.EfSearch, k, 1), 0, accept)
+	if len(nearest) > k {
+		nearest = nearest[:k]
+	}
+
+	metric := h.metric.storage()
+	results := make([]Result, len(nearest))
+	for i, s := range nearest {
+		n := &h.nodes[s.node]
+		results[i] = Result{ID: n.id, Metadata: n.metadata, Score: metric.key(Match{Score: s.similarity})}
+	}
+	return results, nil
+}
+
+func (h *HNSWIndex) Len() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.ids)
+}
+
+func (h *HNSWIndex) Save(w io.Writer) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	s := snapshot{
+		Version:    snapshotVersion,
+		Kind:       snapshotKindHNSW,
+		Metric:     h.metric,
+		Dimensions: h.dimensions,
+		Entries:    make([]snapshotEntry, len(h.nodes)),
+		HNSW: &snapshotHNSW{
+			M:              h.M,
+			EfConstruction: h.EfConstruction,
+			EfSearch:       h.EfSearch,
+			EntryPoint:     int(h.entryPoint),
+			MaxLevel:       h.maxLevel,
+		},
+	}
+	for i, n := range h.nodes {
+		s.Entries[i] = snapshotEntry{
+			ID:        n.id,
+			Vector:    n.vector,
+			Metadata:  n.metadata,
+			Deleted:   n.deleted,
+			Neighbors: n.neighbors,
+		}
+	}
+	return s.write(w)
+}
+
+func loadHNSWIndex(s *snapshot) (*HNSWIndex, error) {
+	if s.HNSW == nil || s.HNSW.M < 2 || s.HNSW.EfConstruction < 1 || s.HNSW.EntryPoint >= len(s.Entries) ||
+		(s.HNSW.EntryPoint < 0 && len(s.Entries) > 0) {
+		return nil, ErrInvalidSnapshot
+	}
+	h := NewHNSWIndex(s.Metric)
+	h.M = s.HNSW.M
+	h.EfConstruction = s.HNSW.EfConstruction
+	h.EfSearch = s.HNSW.EfSearch
+	h.dimensions = s.Dimensions
+	h.entryPoint = int32(s.HNSW.EntryPoint)
+	h.maxLevel = s.HNSW.MaxLevel
+	h.rng = rand.New(rand.NewSource(int64(len(s.Entries)) + 1))
+
+	h.nodes = make([]hnswNode, len(s.Entries))
+	for i, e := range s.Entries {
+		if len(e.Vector) != s.Dimensions || len(e.Neighbors) == 0 {
+			return nil, ErrInvalidSnapshot
+		}
+		for _, neighbors := range e.Neighbors {
+			for _, neighbor := range neighbors {
+				if neighbor < 0 || int(neighbor) >= len(s.Entries) {
+					return nil, ErrInvalidSnapshot
+				}
+			}
+		}
+		h.nodes[i] = hnswNode{
+			entry:     entry{id: e.ID, vector: e.Vector, metadata: e.Metadata},
+			neighbors: e.Neighbors,
+			deleted:   e.Deleted,
+		}
+		if !e.Deleted {
+			if _, ok := h.ids[e.ID]; ok {
+				return nil, fmt.Errorf("%w: duplicate id %q", ErrInvalidSnapshot, e.ID)
+			}
+			h.ids[e.ID] = int32(i)
+		}
+	}
+	if h.entryPoint >= 0 && len(h.nodes[h.entryPoint].neighbors) <= h.maxLevel {
+		return nil, ErrInvalidSnapshot
+	}
+	return h, nil
+}
+
+// bestFirst is a heap of scored nodes popping the nearest first.
+type bestFirst []scored
+
+func (b bestFirst) Len() int           { return len(b) }
+func (b bestFirst) Less(i, j int) bool { return b[i].similarity > b[j].similarity }
+func (b bestFirst) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b *bestFirst) Push(x any)        { *b = append(*b, x.(scored)) }
+func (b *bestFirst) Pop() any {
+	old := *b
+	x := old[len(old)-1]
+	*b = old[:len(old)-1]
+	return x
+}
+
+// worstFirst is a heap of scored nodes popping the farthest first.
+type worstFirst []scored
+
+func (w worstFirst) Len() int           { return len(w) }
+func (w worstFirst) Less(i, j int) bool { return w[i].similarity < w[j].similarity }
+func (w worstFirst) Swap(i, j int)      { w[i], w[j] = w[j], w[i] }
+func (w *worstFirst) Push(x any)        { *w = append(*w, x.(scored)) }
+func (w *worstFirst) Pop() any {
+	old := *w
+	x := old[len(old)-1]
+	*w = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,183 @@
+package vector_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/neospace-ai/go-openai/internal/test/checks"
+	"github.com/neospace-ai/go-openai/vector"
+)
+
+// fillIndexes adds the same random vectors to a flat and an HNSW index, with a "group" metadata
+// key taking one of groups values.
+func fillIndexes(t *testing.T, r *rand.Rand, n, dimensions, groups int) (*vector.FlatIndex, *vector.HNSWIndex) {
+	t.Helper()
+	flat := vector.NewFlatIndex(vector.MetricCosine)
+	hnsw := vector.NewHNSWIndex(vector.MetricCosine)
+	for _, embedding := range randomEmbeddings(r, n, dimensions) {
+		id := strconv.Itoa(embedding.Index)
+		metadata := vector.Metadata{"group": strconv.Itoa(embedding.Index % groups)}
+		checks.NoError(t, flat.Add(id, embedding.Embedding, metadata), "flat Add error")
+		checks.NoError(t, hnsw.Add(id, embedding.Embedding, metadata), "hnsw Add error")
+	}
+	return flat, hnsw
+}
+
+func recall(t *testing.T, flat, hnsw vector.Index, queries [][]float32, k int, filter vector.Filter) float64 {
+	t.Helper()
+	var found, total int
+	for _, query := range queries {
+		exact, err := flat.Search(query, k, filter)
+		checks.NoError(t, err, "flat Search error")
+		approximate, err := hnsw.Search(query, k, filter)
+		checks.NoError(t, err, "hnsw Search error")
+
+		ids := make(map[string]bool, len(approximate))
+		for _, result := range approximate {
+			ids[result.ID] = true
+		}
+		for _, result := range exact {
+			if ids[result.ID] {
+				found++
+			}
+		}
+		total += len(exact)
+	}
+	return float64(found) / float64(total)
+}
+
+func TestHNSWRecall(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	flat, hnsw := fillIndexes(t, r, 2000, 32, 10)
+	queries := make([][]float32, 50)
+	for i, query := range randomEmbeddings(r, len(queries), 32) {
+		queries[i] = query.Embedding
+	}
+
+	if got := recall(t, flat, hnsw, queries, 10, nil); got < 0.9 {
+		t.Errorf("recall = %v, want at least 0.9", got)
+	}
+	if got := recall(t, flat, hnsw, queries, 10, vector.Equal("group", "3")); got < 0.9 {
+		t.Errorf("filtered recall = %v, want at least 0.9", got)
+	}
+
+	for i := 0; i < 2000; i += 2 {
+		hnsw.Delete(strconv.Itoa(i))
+		flat.Delete(strconv.Itoa(i))
+	}
+	if got := recall(t, flat, hnsw, queries, 10, nil); got < 0.9 {
+		t.Errorf("recall after deleting half = %v, want at least 0.9", got)
+	}
+}
+
+func TestHNSWAddExistingID(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	flat, hnsw := fillIndexes(t, r, 500, 16, 1)
+	for round := 0; round < 3; round++ {
+		for _, embedding := range randomEmbeddings(r, 500, 16) {
+			id := strconv.Itoa(embedding.Index)
+			checks.NoError(t, flat.Add(id, embedding.Embedding, nil), "flat Add error")
+			checks.NoError(t, hnsw.Add(id, embedding.Embedding, nil), "hnsw Add error")
+		}
+	}
+
+	var buf bytes.Buffer
+	checks.NoError(t, hnsw.Save(&buf), "Save error")
+	var snapshot struct {
+		Entries []json.RawMessage `json:"entries"`
+	}
+	checks.NoError(t, json.Unmarshal(buf.Bytes(), &snapshot), "Unmarshal error")
+	if hnsw.Len() != 500 || len(snapshot.Entries) != 500 {
+		t.Fatalf("Len = %d with %d nodes, want 500", hnsw.Len(), len(snapshot.Entries))
+	}
+
+	queries := make([][]float32, 50)
+	for i, query := range randomEmbeddings(r, len(queries), 16) {
+		queries[i] = query.Embedding
+	}
+	if got := recall(t, flat, hnsw, queries, 10, nil); got < 0.9 {
+		t.Errorf("recall after updating every vector = %v, want at least 0.9", got)
+	}
+}
+
+func TestHNSWInvalidSettings(t *testing.T) {
+	for _, m := range []int{1, 0, -3} {
+		index := vector.NewHNSWIndex(vector.MetricCosine)
+		index.M = m
+		err := index.Add("a", []float32{1, 0}, nil)
+		checks.ErrorIs(t, err, vector.ErrInvalidHNSWSettings, fmt.Sprintf("Add accepted M %d", m))
+		if index.Len() != 0 {
+			t.Errorf("Len = %d after a failed Add", index.Len())
+		}
+	}
+
+	index := vector.NewHNSWIndex(vector.MetricCosine)
+	index.EfConstruction = 0
+	err := index.Add("a", []float32{1, 0}, nil)
+	checks.ErrorIs(t, err, vector.ErrInvalidHNSWSettings, "Add accepted EfConstruction 0")
+
+	// EfSearch is raised to k.
+	index = vector.NewHNSWIndex(vector.MetricCosine)
+	index.EfSearch = 0
+	for i := 0; i < 3; i++ {
+		checks.NoError(t, index.Add(strconv.Itoa(i), []float32{1, float32(i)}, nil), "Add error")
+	}
+	results, err := index.Search([]float32{1, 0}, 2, nil)
+	checks.NoError(t, err, "Search error")
+	if len(results) != 2 || results[0].ID != "0" {
+		t.Errorf("Search with EfSearch 0 = %+v, want 2 results starting with 0", results)
+	}
+}
+
+func TestHNSWConcurrentUse(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	vectors := randomEmbeddings(r, 400, 16)
+	index := vector.NewHNSWIndex(vector.MetricEuclidean)
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < 4; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := worker; i < len(vectors); i += 4 {
+				if err := index.Add(fmt.Sprint(i), vectors[i].Embedding, nil); err != nil {
+					t.Error(err)
+					return
+				}
+				if _, err := index.Search(vectors[i].Embedding, 3, nil); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if index.Len() != len(vectors) {
+		t.Fatalf("Len = %d, want %d", index.Len(), len(vectors))
+	}
+	results, err := index.Search(vectors[42].Embedding, 1, nil)
+	checks.NoError(t, err, "Search error")
+	if len(results) != 1 || results[0].ID != "42" || results[0].Score != 0 {
+		t.Errorf("Search = %+v, want 42 at distance 0", results)
+	}
+}
+
+func BenchmarkHNSWSearch(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	index := vector.NewHNSWIndex(vector.MetricCosine)
+	for _, embedding := range randomEmbeddings(r, 10000, 256) {
+		_ = index.Add(strconv.Itoa(embedding.Index), embedding.Embedding, nil)
+	}
+	query := randomEmbeddings(r, 1, 256)[0].Embedding
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = index.Search(query, 10, nil)
+	}
+}
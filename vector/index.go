@@ -0,0 +1,149 @@
+package vector
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/neospace-ai/go-openai"
+)
+
+var ErrMissingID = errors.New("embedding has no id")
+
+// Metadata are the attributes stored along with a vector, which searches can be filtered by.
+type Metadata map[string]string
+
+// Filter reports whether a vector with the given metadata may be returned by a search.
+type Filter func(Metadata) bool
+
+// Equal matches the vectors whose metadata key has the given value.
+func Equal(key, value string) Filter {
+	return func(metadata Metadata) bool {
+		v, ok := metadata[key]
+		return ok && v == value
+	}
+}
+
+// In matches the vectors whose metadata key has one of the given values.
+func In(key string, values ...string) Filter {
+	return func(metadata Metadata) bool {
+		v, ok := metadata[key]
+		if !ok {
+			return false
+		}
+		for _, value := range values {
+			if v == value {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// All matches the vectors matched by every filter.
+func All(filters ...Filter) Filter {
+	return func(metadata Metadata) bool {
+		for _, filter := range filters {
+			if !filter(metadata) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Any matches the vectors matched by at least one filter.
+func Any(filters ...Filter) Filter {
+	return func(metadata Metadata) bool {
+		for _, filter := range filters {
+			if filter(metadata) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Result is a vector found by a search.
+type Result struct {
+	ID       string
+	Metadata Metadata
+	// Score is the similarity of the vector to the query, or its distance for MetricEuclidean.
+	Score float32
+}
+
+// Index stores vectors by id for nearest-neighbor search. The implementations are safe for
+// concurrent use.
+type Index interface {
+	// Add stores a copy of a vector and its metadata under an id, replacing the vector stored under the
+	// same id if any.
+	// All the vectors of an index have the same length, which is set by the first one.
+	Add(id string, vector []float32, metadata Metadata) error
+	// Delete removes the vector stored under an id, and reports whether there was one.
+	Delete(id string) bool
+	// Search returns the k vectors nearest to the query, best first, among the vectors the filter
+	// matches. A nil filter matches all vectors.
+	Search(query []float32, k int, filter Filter) ([]Result, error)
+	// Len returns the number of vectors in the index.
+	Len() int
+	// Save writes a snapshot of the index, which Load reads back.
+	Save(w io.Writer) error
+}
+
+// AddResponse adds the embeddings of a response to an index. Each embedding is stored under the id
+// at its input position, along with the metadata at the same position if metadata is not nil.
+func AddResponse(index Index, response openai.EmbeddingResponse, ids []string, metadata []Metadata) error {
+	for _, embedding := range response.Data {
+		if embedding.Index < 0 || embedding.Index >= len(ids) {
+			return fmt.Errorf("%w: input %d", ErrMissingID, embedding.Index)
+		}
+		var m Metadata
+		if embedding.Index < len(metadata) {
+			m = metadata[embedding.Index]
+		}
+		if err := index.Add(ids[embedding.Index], embedding.Embedding, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddBase64Response decodes the embeddings of a response created with EmbeddingEncodingFormatBase64
+// and adds them to an index like AddResponse.
+func AddBase64Response(index Index, response openai.EmbeddingResponseBase64, ids []string, metadata []Metadata) error {
+	decoded, err := response.ToEmbeddingResponse()
+	if err != nil {
+		return err
+	}
+	return AddResponse(index, decoded, ids, metadata)
+}
+
+// prepare returns the copy of a vector that is stored in an index. Vectors are normalized for
+// MetricCosine, so that their cosine similarity is computed as a dot product.
+func (m Metric) prepare(v []float32) []float32 {
+	prepared := make([]float32, len(v))
+	copy(prepared, v)
+	if m == MetricCosine {
+		Normalize(prepared)
+	}
+	return prepared
+}
+
+// storage returns the metric prepared vectors are compared with.
+func (m Metric) storage() Metric {
+	if m == MetricCosine {
+		return MetricDot
+	}
+	return m
+}
+
+// checkDimensions returns the number of dimensions of an index after adding a vector of the given length.
+func checkDimensions(dimensions, length int) (int, error) {
+	if length == 0 {
+		return dimensions, ErrInvalidDimensions
+	}
+	if dimensions != 0 && dimensions != length {
+		return dimensions, openai.ErrVectorLengthMismatch
+	}
+	return length, nil
+}
@@ -0,0 +1,219 @@
+package vector_test
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/neospace-ai/go-openai"
+	"github.com/neospace-ai/go-openai/internal/test/checks"
+	"github.com/neospace-ai/go-openai/vector"
+)
+
+var indexKinds = []struct {
+	name string
+	new  func(vector.Metric) vector.Index
+}{
+	{"flat", func(m vector.Metric) vector.Index { return vector.NewFlatIndex(m) }},
+	{"hnsw", func(m vector.Metric) vector.Index { return vector.NewHNSWIndex(m) }},
+}
+
+func resultIDs(results []vector.Result) []string {
+	ids := make([]string, len(results))
+	for i, result := range results {
+		ids[i] = result.ID
+	}
+	return ids
+}
+
+func addAll(t *testing.T, index vector.Index) {
+	t.Helper()
+	docs := []struct {
+		id       string
+		vector   []float32
+		language string
+	}{
+		{"east", []float32{1, 0}, "en"},
+		{"north-east", []float32{1, 1}, "fr"},
+		{"north", []float32{0, 1}, "en"},
+		{"west", []float32{-2, 0}, "de"},
+		{"far-east", []float32{10, 0.5}, "fr"},
+	}
+	for _, doc := range docs {
+		err := index.Add(doc.id, doc.vector, vector.Metadata{"language": doc.language})
+		checks.NoError(t, err, "Add error")
+	}
+}
+
+func TestIndexSearch(t *testing.T) {
+	for _, tc := range indexKinds {
+		t.Run(tc.name, func(t *testing.T) {
+			index := tc.new(vector.MetricCosine)
+			addAll(t, index)
+			if index.Len() != 5 {
+				t.Fatalf("Len = %d, want 5", index.Len())
+			}
+
+			results, err := index.Search([]float32{1, 0}, 3, nil)
+			checks.NoError(t, err, "Search error")
+			want := []string{"east", "far-east", "north-east"}
+			if got := resultIDs(results); !reflect.DeepEqual(got, want) {
+				t.Errorf("Search = %v, want %v", got, want)
+			}
+			if math.Abs(float64(results[0].Score-1)) > 1e-6 || results[0].Metadata["language"] != "en" {
+				t.Errorf("first result = %+v, want east with score 1", results[0])
+			}
+
+			results, err = index.Search([]float32{1, 0}, 3, vector.Equal("language", "fr"))
+			checks.NoError(t, err, "Search error")
+			if got := resultIDs(results); !reflect.DeepEqual(got, []string{"far-east", "north-east"}) {
+				t.Errorf("filtered Search = %v, want [far-east north-east]", got)
+			}
+
+			filter := vector.Any(vector.In("language", "de", "en"), vector.All())
+			results, err = index.Search([]float32{-1, 0}, 1, vector.All(filter, vector.Equal("language", "de")))
+			checks.NoError(t, err, "Search error")
+			if got := resultIDs(results); !reflect.DeepEqual(got, []string{"west"}) {
+				t.Errorf("filtered Search = %v, want [west]", got)
+			}
+
+			_, err = index.Search([]float32{1, 0, 0}, 1, nil)
+			checks.ErrorIs(t, err, openai.ErrVectorLengthMismatch, "Search should fail on different lengths")
+			err = index.Add("3d", []float32{1, 0, 0}, nil)
+			checks.ErrorIs(t, err, openai.ErrVectorLengthMismatch, "Add should fail on different lengths")
+			err = index.Add("empty", nil, nil)
+			checks.ErrorIs(t, err, vector.ErrInvalidDimensions, "Add should fail on empty vectors")
+		})
+	}
+}
+
+func TestIndexMetrics(t *testing.T) {
+	for _, tc := range indexKinds {
+		t.Run(tc.name, func(t *testing.T) {
+			dot := tc.new(vector.MetricDot)
+			addAll(t, dot)
+			results, err := dot.Search([]float32{1, -0.1}, 2, nil)
+			checks.NoError(t, err, "Search error")
+			if got := resultIDs(results); !reflect.DeepEqual(got, []string{"far-east", "east"}) ||
+				math.Abs(float64(results[0].Score-9.95)) > 1e-5 {
+				t.Errorf("dot Search = %+v, want far-east with score 9.95 then east", results)
+			}
+
+			euclidean := tc.new(vector.MetricEuclidean)
+			addAll(t, euclidean)
+			results, err = euclidean.Search([]float32{0, 0.9}, 2, nil)
+			checks.NoError(t, err, "Search error")
+			if got := resultIDs(results); !reflect.DeepEqual(got, []string{"north", "north-east"}) {
+				t.Errorf("euclidean Search = %v, want [north north-east]", got)
+			}
+			if math.Abs(float64(results[0].Score-0.1)) > 1e-6 {
+				t.Errorf("euclidean score = %v, want 0.1", results[0].Score)
+			}
+		})
+	}
+}
+
+func TestIndexReplaceAndDelete(t *testing.T) {
+	for _, tc := range indexKinds {
+		t.Run(tc.name, func(t *testing.T) {
+			index := tc.new(vector.MetricCosine)
+			addAll(t, index)
+
+			metadata := vector.Metadata{"language": "es"}
+			err := index.Add("west", []float32{1, 0.01}, metadata)
+			checks.NoError(t, err, "Add error")
+			// The index keeps a copy of the metadata.
+			metadata["language"] = "pt"
+			if index.Len() != 5 {
+				t.Errorf("Len after replace = %d, want 5", index.Len())
+			}
+			results, err := index.Search([]float32{1, 0}, 2, nil)
+			checks.NoError(t, err, "Search error")
+			if got := resultIDs(results); !reflect.DeepEqual(got, []string{"east", "west"}) {
+				t.Errorf("Search after replace = %v, want [east west]", got)
+			}
+			if results[1].Metadata["language"] != "es" {
+				t.Errorf("replaced metadata = %v, want es", results[1].Metadata)
+			}
+
+			if !index.Delete("east") {
+				t.Error("Delete(east) = false, want true")
+			}
+			if index.Delete("east") {
+				t.Error("second Delete(east) = true, want false")
+			}
+			if index.Len() != 4 {
+				t.Errorf("Len after delete = %d, want 4", index.Len())
+			}
+			results, err = index.Search([]float32{1, 0}, 10, nil)
+			checks.NoError(t, err, "Search error")
+			want := []string{"west", "far-east", "north-east", "north"}
+			if got := resultIDs(results); !reflect.DeepEqual(got, want) {
+				t.Errorf("Search after delete = %v, want %v", got, want)
+			}
+
+			for _, id := range want {
+				index.Delete(id)
+			}
+			results, err = index.Search([]float32{1, 0}, 10, nil)
+			checks.NoError(t, err, "Search error")
+			if len(results) != 0 {
+				t.Errorf("Search of an empty index = %v, want none", results)
+			}
+		})
+	}
+}
+
+func TestAddResponse(t *testing.T) {
+	index := vector.NewFlatIndex(vector.MetricCosine)
+	response := openai.EmbeddingResponse{Data: []openai.Embedding{
+		{Index: 1, Embedding: []float32{0, 1}},
+		{Index: 0, Embedding: []float32{1, 0}},
+	}}
+	err := vector.AddResponse(index, response, []string{"a", "b"}, []vector.Metadata{{"n": "0"}})
+	checks.NoError(t, err, "AddResponse error")
+
+	results, err := index.Search([]float32{0, 1}, 2, nil)
+	checks.NoError(t, err, "Search error")
+	if got := resultIDs(results); !reflect.DeepEqual(got, []string{"b", "a"}) {
+		t.Errorf("Search = %v, want [b a]", got)
+	}
+	if results[0].Metadata != nil || results[1].Metadata["n"] != "0" {
+		t.Errorf("metadata = %v and %v, want none and n=0", results[0].Metadata, results[1].Metadata)
+	}
+
+	err = vector.AddResponse(index, response, []string{"a"}, nil)
+	checks.ErrorIs(t, err, vector.ErrMissingID, "AddResponse should fail without an id per input")
+}
+
+func TestAddBase64Response(t *testing.T) {
+	encode := func(v ...float32) string {
+		data := make([]byte, 4*len(v))
+		for i, f := range v {
+			binary.LittleEndian.PutUint32(data[4*i:], math.Float32bits(f))
+		}
+		return base64.StdEncoding.EncodeToString(data)
+	}
+	var response openai.EmbeddingResponseBase64
+	err := json.Unmarshal([]byte(`{"data": [
+		{"object": "embedding", "index": 0, "embedding": "`+encode(0.6, 0.8)+`"},
+		{"object": "embedding", "index": 1, "embedding": "`+encode(-1, 0)+`"}
+	]}`), &response)
+	checks.NoError(t, err, "Unmarshal error")
+
+	index := vector.NewHNSWIndex(vector.MetricDot)
+	err = vector.AddBase64Response(index, response, []string{"a", "b"}, nil)
+	checks.NoError(t, err, "AddBase64Response error")
+	results, err := index.Search([]float32{1, 0}, 1, nil)
+	checks.NoError(t, err, "Search error")
+	if len(results) != 1 || results[0].ID != "a" || math.Abs(float64(results[0].Score-0.6)) > 1e-6 {
+		t.Errorf("Search = %+v, want a with score 0.6", results)
+	}
+
+	response.Data[0].Embedding = "not base64"
+	err = vector.AddBase64Response(index, response, []string{"a", "b"}, nil)
+	checks.HasError(t, err, "AddBase64Response should fail on invalid base64")
+}
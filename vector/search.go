@@ -0,0 +1,122 @@
+package vector
+
+import (
+	"math"
+
+	"github.com/neospace-ai/go-openai"
+)
+
+// Metric is the measure nearest neighbors are ranked by.
+type Metric int
+
+const (
+	// MetricCosine ranks by decreasing cosine similarity.
+	MetricCosine Metric = iota
+	// MetricDot ranks by decreasing dot product, which equals the cosine similarity of normalized
+	// vectors like the embeddings of the OpenAI models, for less work.
+	MetricDot
+	// MetricEuclidean ranks by increasing Euclidean distance.
+	MetricEuclidean
+)
+
+// Match is a nearest neighbor of a query.
+type Match struct {
+	// Index is the position of the neighbor in the searched slice.
+	Index int
+	// Score is the similarity of the neighbor, or its distance for MetricEuclidean.
+	Score float32
+}
+
+func (m Metric) valid() bool {
+	return m >= MetricCosine && m <= MetricEuclidean
+}
+
+func (m Metric) score(query, candidate []float32, squaredQueryNorm float32) float32 {
+	switch m {
+	case MetricDot:
+		return dot(query, candidate)
+	case MetricEuclidean:
+		return float32(math.Sqrt(float64(squaredDistance(query, candidate))))
+	default:
+		d, _, squaredCandidateNorm := dotNorms(query, candidate)
+		return cosine(d, squaredQueryNorm, squaredCandidateNorm)
+	}
+}
+
+// key returns the rank of a match, higher for better matches, so that all metrics are ranked the same way.
+func (m Metric) key(match Match) float32 {
+	if m == MetricEuclidean {
+		return -match.Score
+	}
+	return match.Score
+}
+
+// TopK returns the k embeddings nearest to the query, best first.
+func TopK(query []float32, embeddings []openai.Embedding, k int, metric Metric) ([]Match, error) {
+	return AppendTopK(nil, query, embeddings, k, metric)
+}
+
+// AppendTopK appends the k embeddings nearest to the query to dst, best first, and returns the
+// extended slice. It does not allocate when dst has room for k more matches, so that a buffer
+// can be reused across searches.
+func AppendTopK(dst []Match, query []float32, embeddings []openai.Embedding, k int, metric Metric) ([]Match, error) {
+	start := len(dst)
+	squaredQueryNorm := dot(query, query)
+	for i := range embeddings {
+		candidate := embeddings[i].Embedding
+		if len(candidate) != len(query) {
+			return dst[:start], openai.ErrVectorLengthMismatch
+		}
+		match := Match{Index: i, Score: metric.score(query, candidate, squaredQueryNorm)}
+		dst = insertMatch(dst, start, k, match, metric)
+	}
+	return dst, nil
+}
+
+// BatchTopK returns the k embeddings nearest to each query, best first. The embeddings are scanned
+// once for all queries, so that each of them is read from memory once rather than once per query.
+func BatchTopK(queries [][]float32, embeddings []openai.Embedding, k int, metric Metric) ([][]Match, error) {
+	results := make([][]Match, len(queries))
+	if k <= 0 {
+		return results, nil
+	}
+	squaredQueryNorms := make([]float32, len(queries))
+	for q, query := range queries {
+		squaredQueryNorms[q] = dot(query, query)
+		results[q] = make([]Match, 0, min(k, len(embeddings)))
+	}
+
+	for i := range embeddings {
+		candidate := embeddings[i].Embedding
+		for q, query := range queries {
+			if len(candidate) != len(query) {
+				return nil, openai.ErrVectorLengthMismatch
+			}
+			match := Match{Index: i, Score: metric.score(query, candidate, squaredQueryNorms[q])}
+			results[q] = insertMatch(results[q], 0, k, match, metric)
+		}
+	}
+	return results, nil
+}
+
+// insertMatch inserts a match into the matches of dst after start, which are kept sorted best first
+// and at most k long. k is small in practice, which makes an insertion faster than a heap.
+func insertMatch(dst []Match, start, k int, match Match, metric Metric) []Match {
+	key := metric.key(match)
+	matches := dst[start:]
+	n := len(matches)
+	if k <= 0 || (n == k && key <= metric.key(matches[n-1])) {
+		return dst
+	}
+	if n < k {
+		dst = append(dst, match)
+		matches = dst[start:]
+		n++
+	}
+	i := n - 1
+	for ; i > 0 && key > metric.key(matches[i-1]); i-- {
+		matches[i] = matches[i-1]
+	}
+	matches[i] = match
+	return dst
+}
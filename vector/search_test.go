@@ -0,0 +1,164 @@
+package vector_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/neospace-ai/go-openai"
+	"github.com/neospace-ai/go-openai/internal/test/checks"
+	"github.com/neospace-ai/go-openai/vector"
+)
+
+func embeddings(vectors ...[]float32) []openai.Embedding {
+	result := make([]openai.Embedding, len(vectors))
+	for i, v := range vectors {
+		result[i] = openai.Embedding{Object: "embedding", Index: i, Embedding: v}
+	}
+	return result
+}
+
+func indexes(matches []vector.Match) []int {
+	result := make([]int, len(matches))
+	for i, match := range matches {
+		result[i] = match.Index
+	}
+	return result
+}
+
+func TestTopK(t *testing.T) {
+	candidates := embeddings(
+		[]float32{0, 1},
+		[]float32{1, 0},
+		[]float32{10, 1},
+		[]float32{-1, 0},
+		[]float32{2, 2},
+	)
+	query := []float32{1, 0}
+
+	cases := []struct {
+		metric vector.Metric
+		k      int
+		want   []int
+	}{
+		{vector.MetricCosine, 3, []int{1, 2, 4}},
+		{vector.MetricDot, 3, []int{2, 4, 1}},
+		{vector.MetricEuclidean, 3, []int{1, 0, 3}},
+		{vector.MetricCosine, 10, []int{1, 2, 4, 0, 3}},
+		{vector.MetricCosine, 0, []int{}},
+	}
+	for _, tc := range cases {
+		matches, err := vector.TopK(query, candidates, tc.k, tc.metric)
+		checks.NoError(t, err, "TopK error")
+		if got := indexes(matches); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("TopK(metric %d, k %d) = %v, want %v", tc.metric, tc.k, got, tc.want)
+		}
+	}
+
+	matches, err := vector.TopK(query, candidates, 1, vector.MetricEuclidean)
+	checks.NoError(t, err, "TopK error")
+	if matches[0].Score != 0 {
+		t.Errorf("Euclidean score = %v, want 0", matches[0].Score)
+	}
+
+	_, err = vector.TopK([]float32{1, 0, 0}, candidates, 1, vector.MetricCosine)
+	checks.ErrorIs(t, err, openai.ErrVectorLengthMismatch, "TopK should fail on different lengths")
+}
+
+func TestAppendTopK(t *testing.T) {
+	candidates := embeddings([]float32{1, 0}, []float32{0, 1}, []float32{1, 1})
+	dst := []vector.Match{{Index: 42, Score: 1}}
+
+	dst, err := vector.AppendTopK(dst, []float32{0, 1}, candidates, 2, vector.MetricCosine)
+	checks.NoError(t, err, "AppendTopK error")
+	if got := indexes(dst); !reflect.DeepEqual(got, []int{42, 1, 2}) {
+		t.Errorf("AppendTopK = %v, want [42 1 2]", got)
+	}
+
+	dst, err = vector.AppendTopK(dst, []float32{0}, candidates, 2, vector.MetricCosine)
+	checks.ErrorIs(t, err, openai.ErrVectorLengthMismatch, "AppendTopK should fail on different lengths")
+	if len(dst) != 3 {
+		t.Errorf("AppendTopK changed dst to %v on error", dst)
+	}
+}
+
+func randomEmbeddings(r *rand.Rand, n, dimensions int) []openai.Embedding {
+	vectors := make([][]float32, n)
+	for i := range vectors {
+		vectors[i] = make([]float32, dimensions)
+		for j := range vectors[i] {
+			vectors[i][j] = r.Float32()*2 - 1
+		}
+	}
+	return embeddings(vectors...)
+}
+
+func TestBatchTopK(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	candidates := randomEmbeddings(r, 200, 37)
+	queries := make([][]float32, 5)
+	for i, query := range randomEmbeddings(r, len(queries), 37) {
+		queries[i] = query.Embedding
+	}
+
+	for _, metric := range []vector.Metric{vector.MetricCosine, vector.MetricDot, vector.MetricEuclidean} {
+		batch, err := vector.BatchTopK(queries, candidates, 7, metric)
+		checks.NoError(t, err, "BatchTopK error")
+		for i, query := range queries {
+			want, err := vector.TopK(query, candidates, 7, metric)
+			checks.NoError(t, err, "TopK error")
+			if !reflect.DeepEqual(batch[i], want) {
+				t.Errorf("BatchTopK(metric %d)[%d] = %v, want %v", metric, i, batch[i], want)
+			}
+		}
+	}
+
+	for _, k := range []int{0, -1} {
+		batch, err := vector.BatchTopK(queries, candidates, k, vector.MetricCosine)
+		checks.NoError(t, err, "BatchTopK error")
+		if len(batch) != len(queries) || len(batch[0]) != 0 {
+			t.Errorf("BatchTopK(k %d) = %v, want %d empty results", k, batch, len(queries))
+		}
+	}
+
+	_, err := vector.BatchTopK([][]float32{{1}}, candidates, 1, vector.MetricDot)
+	checks.ErrorIs(t, err, openai.ErrVectorLengthMismatch, "BatchTopK should fail on different lengths")
+}
+
+func TestAppendTopKDoesNotAllocate(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	candidates := randomEmbeddings(r, 100, 64)
+	query := candidates[0].Embedding
+	dst := make([]vector.Match, 0, 10)
+
+	for _, metric := range []vector.Metric{vector.MetricCosine, vector.MetricDot, vector.MetricEuclidean} {
+		allocs := testing.AllocsPerRun(100, func() {
+			dst, _ = vector.AppendTopK(dst[:0], query, candidates, 10, metric)
+		})
+		if allocs != 0 {
+			t.Errorf("AppendTopK(metric %d) allocations = %v, want 0", metric, allocs)
+		}
+	}
+}
+
+func BenchmarkTopK(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	candidates := randomEmbeddings(r, 10000, 1536)
+	query := candidates[0].Embedding
+	dst := make([]vector.Match, 0, 10)
+
+	for _, bench := range []struct {
+		name   string
+		metric vector.Metric
+	}{
+		{"cosine", vector.MetricCosine},
+		{"dot", vector.MetricDot},
+		{"euclidean", vector.MetricEuclidean},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				dst, _ = vector.AppendTopK(dst[:0], query, candidates, 10, bench.metric)
+			}
+		})
+	}
+}
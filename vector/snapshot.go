@@ -0,0 +1,103 @@
+package vector
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+const (
+	snapshotVersion  = 1
+	snapshotKindFlat = "flat"
+	snapshotKindHNSW = "hnsw"
+)
+
+var ErrInvalidSnapshot = errors.New("invalid index snapshot")
+
+// snapshot is the JSON representation of an index. Vectors are stored prepared, and the HNSW
+// graph is stored along with them, so that loading an index does not rebuild it.
+type snapshot struct {
+	Version    int             `json:"version"`
+	Kind       string          `json:"kind"`
+	Metric     Metric          `json:"metric"`
+	Dimensions int             `json:"dimensions"`
+	Entries    []snapshotEntry `json:"entries"`
+
+	HNSW *snapshotHNSW `json:"hnsw,omitempty"`
+}
+
+type snapshotEntry struct {
+	ID       string    `json:"id"`
+	Vector   []float32 `json:"vector"`
+	Metadata Metadata  `json:"metadata,omitempty"`
+	// The fields below are only set for HNSW indexes.
+	Deleted   bool      `json:"deleted,omitempty"`
+	Neighbors [][]int32 `json:"neighbors,omitempty"`
+}
+
+type snapshotHNSW struct {
+	M              int `json:"m"`
+	EfConstruction int `json:"ef_construction"`
+	EfSearch       int `json:"ef_search"`
+	EntryPoint     int `json:"entry_point"`
+	MaxLevel       int `json:"max_level"`
+}
+
+func (s *snapshot) write(w io.Writer) error {
+	return json.NewEncoder(w).Encode(s)
+}
+
+// Load reads an index written by the Save method of FlatIndex or HNSWIndex.
+func Load(r io.Reader) (Index, error) {
+	var s snapshot
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSnapshot, err)
+	}
+	if s.Version != snapshotVersion {
+		return nil, fmt.Errorf("%w: version %d", ErrInvalidSnapshot, s.Version)
+	}
+	if !s.Metric.valid() {
+		return nil, fmt.Errorf("%w: metric %d", ErrInvalidSnapshot, s.Metric)
+	}
+
+	switch s.Kind {
+	case snapshotKindFlat:
+		return loadFlatIndex(&s)
+	case snapshotKindHNSW:
+		return loadHNSWIndex(&s)
+	default:
+		return nil, fmt.Errorf("%w: kind %q", ErrInvalidSnapshot, s.Kind)
+	}
+}
+
+// SaveFile writes a snapshot of an index to a temporary file and renames it to path, so that a failed
+// save never leaves a truncated snapshot behind.
+func SaveFile(index Index, path string) error {
+	file, err := os.CreateTemp(filepath.Dir(path), ".index-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if err = index.Save(file); err != nil {
+		file.Close()
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+// LoadFile reads an index written by SaveFile.
+func LoadFile(path string) (Index, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Load(file)
+}
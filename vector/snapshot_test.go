@@ -0,0 +1,98 @@
+package vector_test
+
+import (
+	"bytes"
+	"math/rand"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/neospace-ai/go-openai/internal/test/checks"
+	"github.com/neospace-ai/go-openai/vector"
+)
+
+func TestSaveAndLoad(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	queries := randomEmbeddings(r, 10, 24)
+	flat, hnsw := fillIndexes(t, r, 300, 24, 3)
+	flat.Delete("7")
+	hnsw.Delete("7")
+
+	for _, index := range []vector.Index{flat, hnsw} {
+		var buf bytes.Buffer
+		checks.NoError(t, index.Save(&buf), "Save error")
+		loaded, err := vector.Load(&buf)
+		checks.NoError(t, err, "Load error")
+
+		if reflect.TypeOf(loaded) != reflect.TypeOf(index) || loaded.Len() != index.Len() {
+			t.Fatalf("loaded %T with %d vectors, want %T with %d", loaded, loaded.Len(), index, index.Len())
+		}
+		for _, query := range queries {
+			filter := vector.Equal("group", "1")
+			want, err := index.Search(query.Embedding, 5, filter)
+			checks.NoError(t, err, "Search error")
+			got, err := loaded.Search(query.Embedding, 5, filter)
+			checks.NoError(t, err, "Search error")
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("%T: loaded Search = %v, want %v", index, got, want)
+			}
+		}
+
+		checks.NoError(t, loaded.Add("new", queries[0].Embedding, nil), "Add to a loaded index error")
+		results, err := loaded.Search(queries[0].Embedding, 1, nil)
+		checks.NoError(t, err, "Search error")
+		if len(results) != 1 || results[0].ID != "new" {
+			t.Errorf("%T: Search after Add to a loaded index = %v, want new", index, results)
+		}
+	}
+}
+
+func TestSaveFile(t *testing.T) {
+	index := vector.NewHNSWIndex(vector.MetricCosine)
+	checks.NoError(t, index.Add("a", []float32{1, 2, 3}, vector.Metadata{"k": "v"}), "Add error")
+	path := filepath.Join(t.TempDir(), "index.json")
+
+	checks.NoError(t, vector.SaveFile(index, path), "SaveFile error")
+	loaded, err := vector.LoadFile(path)
+	checks.NoError(t, err, "LoadFile error")
+	results, err := loaded.Search([]float32{1, 2, 3}, 1, vector.Equal("k", "v"))
+	checks.NoError(t, err, "Search error")
+	if len(results) != 1 || results[0].ID != "a" {
+		t.Errorf("Search = %v, want a", results)
+	}
+
+	empty := vector.NewFlatIndex(vector.MetricDot)
+	checks.NoError(t, vector.SaveFile(empty, path), "SaveFile error")
+	loaded, err = vector.LoadFile(path)
+	checks.NoError(t, err, "LoadFile error")
+	if loaded.Len() != 0 {
+		t.Errorf("Len = %d, want 0", loaded.Len())
+	}
+
+	_, err = vector.LoadFile(filepath.Join(t.TempDir(), "missing.json"))
+	checks.HasError(t, err, "LoadFile should fail on a missing file")
+}
+
+func TestLoadInvalidSnapshot(t *testing.T) {
+	snapshots := []string{
+		`not json`,
+		`{"version": 2, "kind": "flat"}`,
+		`{"version": 1, "kind": "ivf"}`,
+		`{"version": 1, "kind": "flat", "dimensions": 2, "entries": [{"id": "a", "vector": [1]}]}`,
+		`{"version": 1, "kind": "hnsw", "dimensions": 1, "entries": [{"id": "a", "vector": [1], "neighbors": [[]]}]}`,
+		`{"version": 1, "kind": "hnsw", "dimensions": 1, "entries": [{"id": "a", "vector": [1], "neighbors": [[1]]}],
+			"hnsw": {"m": 16, "ef_construction": 200, "entry_point": 0}}`,
+		`{"version": 1, "kind": "flat", "metric": 3, "dimensions": 1, "entries": [{"id": "a", "vector": [1]}]}`,
+		`{"version": 1, "kind": "flat", "dimensions": 1, "entries": [{"id": "a", "vector": [1]},
+			{"id": "a", "vector": [2]}]}`,
+		`{"version": 1, "kind": "hnsw", "dimensions": 1, "entries": [{"id": "a", "vector": [1], "neighbors": [[1]]},
+			{"id": "a", "vector": [2], "neighbors": [[0]]}], "hnsw": {"m": 16, "ef_construction": 200, "entry_point": 0}}`,
+		`{"version": 1, "kind": "hnsw", "dimensions": 1, "entries": [{"id": "a", "vector": [1], "neighbors": [[]]}],
+			"hnsw": {"m": 16, "ef_construction": 0, "entry_point": 0}}`,
+	}
+	for _, snapshot := range snapshots {
+		_, err := vector.Load(strings.NewReader(snapshot))
+		checks.ErrorIs(t, err, vector.ErrInvalidSnapshot, snapshot)
+	}
+}
@@ -0,0 +1,141 @@
+// Package vector provides similarity measures and nearest-neighbor search over embedding vectors.
+//
+// The similarity functions do not allocate, and their loops are unrolled with independent accumulators
+// and hoisted bounds checks, so that the compiler can keep them in registers and vectorize them.
+//
+// FlatIndex and HNSWIndex store vectors in memory along with metadata for retrieval-augmented
+// generation in development and tests, without the hosted vector stores. They can be saved to disk
+// and loaded back.
+package vector
+
+import (
+	"errors"
+	"math"
+
+	"github.com/neospace-ai/go-openai"
+)
+
+var ErrInvalidDimensions = errors.New("invalid number of dimensions")
+
+// Dot returns the dot product of two vectors of the same length.
+func Dot(a, b []float32) (float32, error) {
+	if len(a) != len(b) {
+		return 0, openai.ErrVectorLengthMismatch
+	}
+	return dot(a, b), nil
+}
+
+// Norm returns the L2 norm of a vector.
+func Norm(v []float32) float32 {
+	return float32(math.Sqrt(float64(dot(v, v))))
+}
+
+// Cosine returns the cosine similarity of two vectors of the same length, between -1 and 1.
+// It is 0 if one of the vectors is zero. The embeddings of the OpenAI models are normalized,
+// so that their cosine similarity is their dot product, which is faster to compute.
+func Cosine(a, b []float32) (float32, error) {
+	if len(a) != len(b) {
+		return 0, openai.ErrVectorLengthMismatch
+	}
+	d, na, nb := dotNorms(a, b)
+	return cosine(d, na, nb), nil
+}
+
+// Euclidean returns the Euclidean distance between two vectors of the same length.
+func Euclidean(a, b []float32) (float32, error) {
+	if len(a) != len(b) {
+		return 0, openai.ErrVectorLengthMismatch
+	}
+	return float32(math.Sqrt(float64(squaredDistance(a, b)))), nil
+}
+
+// Normalize scales a vector in place to a unit L2 norm. A zero vector is left unchanged.
+func Normalize(v []float32) {
+	norm := Norm(v)
+	if norm == 0 {
+		return
+	}
+	scale := 1 / norm
+	for i := range v {
+		v[i] *= scale
+	}
+}
+
+// Truncate shortens an embedding of a text-embedding-3 model to its first dimensions and
+// normalizes it again, which keeps most of its meaning at a fraction of its size. The result
+// shares the memory of v, which is modified. Use the Dimensions of the EmbeddingRequest instead
+// to get shorter embeddings from the API directly.
+func Truncate(v []float32, dimensions int) ([]float32, error) {
+	if dimensions <= 0 || dimensions > len(v) {
+		return nil, ErrInvalidDimensions
+	}
+	v = v[:dimensions]
+	Normalize(v)
+	return v, nil
+}
+
+func dot(a, b []float32) float32 {
+	b = b[:len(a)]
+	var s0, s1, s2, s3 float32
+	i := 0
+	for ; i+4 <= len(a); i += 4 {
+		s0 += a[i] * b[i]
+		s1 += a[i+1] * b[i+1]
+		s2 += a[i+2] * b[i+2]
+		s3 += a[i+3] * b[i+3]
+	}
+	for ; i < len(a); i++ {
+		s0 += a[i] * b[i]
+	}
+	return s0 + s1 + s2 + s3
+}
+
+// dotNorms returns the dot product of two vectors along with their squared norms, in a single pass.
+func dotNorms(a, b []float32) (d, na, nb float32) {
+	b = b[:len(a)]
+	var d0, d1, a0, a1, b0, b1 float32
+	i := 0
+	for ; i+2 <= len(a); i += 2 {
+		x0, x1, y0, y1 := a[i], a[i+1], b[i], b[i+1]
+		d0 += x0 * y0
+		d1 += x1 * y1
+		a0 += x0 * x0
+		a1 += x1 * x1
+		b0 += y0 * y0
+		b1 += y1 * y1
+	}
+	for ; i < len(a); i++ {
+		d0 += a[i] * b[i]
+		a0 += a[i] * a[i]
+		b0 += b[i] * b[i]
+	}
+	return d0 + d1, a0 + a1, b0 + b1
+}
+
+func cosine(d, squaredNormA, squaredNormB float32) float32 {
+	if squaredNormA == 0 || squaredNormB == 0 {
+		return 0
+	}
+	return d / float32(math.Sqrt(float64(squaredNormA)*float64(squaredNormB)))
+}
+
+func squaredDistance(a, b []float32) float32 {
+	b = b[:len(a)]
+	var s0, s1, s2, s3 float32
+	i := 0
+	for ; i+4 <= len(a); i += 4 {
+		d0 := a[i] - b[i]
+		d1 := a[i+1] - b[i+1]
+		d2 := a[i+2] - b[i+2]
+		d3 := a[i+3] - b[i+3]
+		s0 += d0 * d0
+		s1 += d1 * d1
+		s2 += d2 * d2
+		s3 += d3 * d3
+	}
+	for ; i < len(a); i++ {
+		d := a[i] - b[i]
+		s0 += d * d
+	}
+	return s0 + s1 + s2 + s3
+}
@@ -0,0 +1,126 @@
+package vector_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/neospace-ai/go-openai"
+	"github.com/neospace-ai/go-openai/internal/test/checks"
+	"github.com/neospace-ai/go-openai/vector"
+)
+
+const epsilon = 1e-6
+
+func almostEqual(a, b float32) bool {
+	return math.Abs(float64(a-b)) < epsilon
+}
+
+func TestDotAndNorm(t *testing.T) {
+	a := []float32{1, 2, 3, 4, 5}
+	b := []float32{5, 4, 3, 2, 1}
+
+	d, err := vector.Dot(a, b)
+	checks.NoError(t, err, "Dot error")
+	if d != 35 {
+		t.Errorf("Dot = %v, want 35", d)
+	}
+	if n := vector.Norm([]float32{3, 4}); n != 5 {
+		t.Errorf("Norm = %v, want 5", n)
+	}
+
+	_, err = vector.Dot(a, b[:4])
+	checks.ErrorIs(t, err, openai.ErrVectorLengthMismatch, "Dot should fail on different lengths")
+}
+
+func TestCosine(t *testing.T) {
+	cases := []struct {
+		name string
+		a, b []float32
+		want float32
+	}{
+		{"same direction", []float32{1, 2, 3}, []float32{2, 4, 6}, 1},
+		{"opposite", []float32{1, 2, 3}, []float32{-1, -2, -3}, -1},
+		{"orthogonal", []float32{1, 0, 0, 0, 0}, []float32{0, 1, 0, 0, 0}, 0},
+		{"zero vector", []float32{0, 0, 0}, []float32{1, 2, 3}, 0},
+		{"angle", []float32{1, 0}, []float32{1, 1}, float32(1 / math.Sqrt2)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := vector.Cosine(tc.a, tc.b)
+			checks.NoError(t, err, "Cosine error")
+			if !almostEqual(got, tc.want) {
+				t.Errorf("Cosine = %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	_, err := vector.Cosine([]float32{1}, []float32{1, 2})
+	checks.ErrorIs(t, err, openai.ErrVectorLengthMismatch, "Cosine should fail on different lengths")
+}
+
+func TestEuclidean(t *testing.T) {
+	got, err := vector.Euclidean([]float32{1, 1, 1, 1, 1}, []float32{1, 4, 1, 5, 1})
+	checks.NoError(t, err, "Euclidean error")
+	if got != 5 {
+		t.Errorf("Euclidean = %v, want 5", got)
+	}
+
+	_, err = vector.Euclidean([]float32{1}, nil)
+	checks.ErrorIs(t, err, openai.ErrVectorLengthMismatch, "Euclidean should fail on different lengths")
+}
+
+func TestNormalize(t *testing.T) {
+	v := []float32{3, 0, 4}
+	vector.Normalize(v)
+	if !almostEqual(v[0], 0.6) || v[1] != 0 || !almostEqual(v[2], 0.8) {
+		t.Errorf("Normalize = %v, want [0.6 0 0.8]", v)
+	}
+
+	zero := []float32{0, 0}
+	vector.Normalize(zero)
+	if zero[0] != 0 || zero[1] != 0 {
+		t.Errorf("Normalize changed a zero vector to %v", zero)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	v := []float32{0.6, 0.8, 0, 0}
+	vector.Normalize(v)
+
+	got, err := vector.Truncate(v, 1)
+	checks.NoError(t, err, "Truncate error")
+	if len(got) != 1 || !almostEqual(got[0], 1) {
+		t.Errorf("Truncate = %v, want [1]", got)
+	}
+
+	got, err = vector.Truncate([]float32{3, 4, 12}, 2)
+	checks.NoError(t, err, "Truncate error")
+	if len(got) != 2 || !almostEqual(vector.Norm(got), 1) || !almostEqual(got[0], 0.6) {
+		t.Errorf("Truncate = %v, want [0.6 0.8]", got)
+	}
+
+	for _, dimensions := range []int{0, -1, 4} {
+		_, err = vector.Truncate([]float32{1, 2, 3}, dimensions)
+		if !errors.Is(err, vector.ErrInvalidDimensions) {
+			t.Errorf("Truncate(%d) error = %v, want ErrInvalidDimensions", dimensions, err)
+		}
+	}
+}
+
+func TestCosineDoesNotAllocate(t *testing.T) {
+	a := make([]float32, 1536)
+	b := make([]float32, 1536)
+	for i := range a {
+		a[i] = float32(i)
+		b[i] = float32(len(b) - i)
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = vector.Cosine(a, b)
+		_, _ = vector.Euclidean(a, b)
+		vector.Normalize(a)
+	})
+	if allocs != 0 {
+		t.Errorf("allocations = %v, want 0", allocs)
+	}
+}
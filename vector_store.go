@@ -5,12 +5,21 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
 const (
 	vectorStoresSuffix            = "/vector_stores"
 	vectorStoresFilesSuffix       = "/files"
 	vectorStoresFileBatchesSuffix = "/file_batches"
+	vectorStoresSearchSuffix      = "/search"
+)
+
+const (
+	VectorStoreFileStatusInProgress = "in_progress"
+	VectorStoreFileStatusCompleted  = "completed"
+	VectorStoreFileStatusCancelled  = "cancelled"
+	VectorStoreFileStatusFailed     = "failed"
 )
 
 type VectorStoreFileCount struct {
@@ -43,10 +52,11 @@ type VectorStoreExpires struct {
 
 // VectorStoreRequest provides the vector store request parameters.
 type VectorStoreRequest struct {
-	Name         string              `json:"name,omitempty"`
-	FileIDs      []string            `json:"file_ids,omitempty"`
-	ExpiresAfter *VectorStoreExpires `json:"expires_after,omitempty"`
-	Metadata     map[string]any      `json:"metadata,omitempty"`
+	Name             string              `json:"name,omitempty"`
+	FileIDs          []string            `json:"file_ids,omitempty"`
+	ExpiresAfter     *VectorStoreExpires `json:"expires_after,omitempty"`
+	ChunkingStrategy *ChunkingStrategy   `json:"chunking_strategy,omitempty"`
+	Metadata         map[string]any      `json:"metadata,omitempty"`
 }
 
 // VectorStoresList is a list of vector store.
@@ -67,23 +77,48 @@ type VectorStoreDeleteResponse struct {
 }
 
 type VectorStoreFile struct {
-	ID            string `json:"id"`
-	Object        string `json:"object"`
-	CreatedAt     int64  `json:"created_at"`
-	VectorStoreID string `json:"vector_store_id"`
-	UsageBytes    int    `json:"usage_bytes"`
-	Status        string `json:"status"`
+	ID               string                `json:"id"`
+	Object           string                `json:"object"`
+	CreatedAt        int64                 `json:"created_at"`
+	VectorStoreID    string                `json:"vector_store_id"`
+	UsageBytes       int                   `json:"usage_bytes"`
+	Status           string                `json:"status"`
+	LastError        *VectorStoreFileError `json:"last_error,omitempty"`
+	ChunkingStrategy *ChunkingStrategy     `json:"chunking_strategy,omitempty"`
+	// Attributes are values of type string, number or boolean, which searches can be filtered by.
+	Attributes map[string]any `json:"attributes,omitempty"`
 
 	httpHeader
 }
 
+// VectorStoreFileError is the reason a file could not be added to a vector store.
+type VectorStoreFileError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *VectorStoreFileError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
 type VectorStoreFileRequest struct {
 	FileID string `json:"file_id"`
+	// ChunkingStrategy is how the file is split into chunks. The auto strategy is used when it is nil.
+	ChunkingStrategy *ChunkingStrategy `json:"chunking_strategy,omitempty"`
+	Attributes       map[string]any    `json:"attributes,omitempty"`
+}
+
+type VectorStoreFileAttributesRequest struct {
+	Attributes map[string]any `json:"attributes"`
 }
 
 type VectorStoreFilesList struct {
 	VectorStoreFiles []VectorStoreFile `json:"data"`
 
+	FirstID string `json:"first_id"`
+	LastID  string `json:"last_id"`
+	HasMore bool   `json:"has_more"`
+
 	httpHeader
 }
 
@@ -100,6 +135,110 @@ type VectorStoreFileBatch struct {
 
 type VectorStoreFileBatchRequest struct {
 	FileIDs []string `json:"file_ids"`
+	// ChunkingStrategy is how the files are split into chunks. The auto strategy is used when it is nil.
+	ChunkingStrategy *ChunkingStrategy `json:"chunking_strategy,omitempty"`
+	// Attributes are set on every file of the batch.
+	Attributes map[string]any `json:"attributes,omitempty"`
+}
+
+type VectorStoreFilterType string
+
+const (
+	VectorStoreFilterTypeEqual              VectorStoreFilterType = "eq"
+	VectorStoreFilterTypeNotEqual           VectorStoreFilterType = "ne"
+	VectorStoreFilterTypeGreaterThan        VectorStoreFilterType = "gt"
+	VectorStoreFilterTypeGreaterThanOrEqual VectorStoreFilterType = "gte"
+	VectorStoreFilterTypeLessThan           VectorStoreFilterType = "lt"
+	VectorStoreFilterTypeLessThanOrEqual    VectorStoreFilterType = "lte"
+	VectorStoreFilterTypeAnd                VectorStoreFilterType = "and"
+	VectorStoreFilterTypeOr                 VectorStoreFilterType = "or"
+)
+
+// VectorStoreFilter filters search results by file attributes. A comparison filter compares the
+// attribute Key to Value, and a compound filter combines Filters with "and" or "or".
+type VectorStoreFilter struct {
+	Type    VectorStoreFilterType `json:"type"`
+	Key     string                `json:"key,omitempty"`
+	Value   any                   `json:"value,omitempty"`
+	Filters []VectorStoreFilter   `json:"filters,omitempty"`
+}
+
+// VectorStoreFilterCompare returns a comparison filter, such as
+// VectorStoreFilterCompare("year", VectorStoreFilterTypeGreaterThanOrEqual, 2020).
+func VectorStoreFilterCompare(key string, comparison VectorStoreFilterType, value any) VectorStoreFilter {
+	return VectorStoreFilter{Type: comparison, Key: key, Value: value}
+}
+
+// VectorStoreFilterAnd returns a filter matching the files all filters match.
+func VectorStoreFilterAnd(filters ...VectorStoreFilter) VectorStoreFilter {
+	return VectorStoreFilter{Type: VectorStoreFilterTypeAnd, Filters: filters}
+}
+
+// VectorStoreFilterOr returns a filter matching the files any of the filters match.
+func VectorStoreFilterOr(filters ...VectorStoreFilter) VectorStoreFilter {
+	return VectorStoreFilter{Type: VectorStoreFilterTypeOr, Filters: filters}
+}
+
+type VectorStoreRanker string
+
+const (
+	VectorStoreRankerAuto            VectorStoreRanker = "auto"
+	VectorStoreRankerDefault20241115 VectorStoreRanker = "default-2024-11-15"
+)
+
+type VectorStoreRankingOptions struct {
+	Ranker VectorStoreRanker `json:"ranker,omitempty"`
+	// ScoreThreshold is the minimum score of the results, between 0 and 1.
+	ScoreThreshold *float64 `json:"score_threshold,omitempty"`
+}
+
+// VectorStoreSearchRequest provides the vector store search parameters.
+type VectorStoreSearchRequest struct {
+	// Query is a string or a slice of strings.
+	Query   any                `json:"query"`
+	Filters *VectorStoreFilter `json:"filters,omitempty"`
+	// MaxNumResults is between 1 and 50, 10 by default.
+	MaxNumResults  int                        `json:"max_num_results,omitempty"`
+	RankingOptions *VectorStoreRankingOptions `json:"ranking_options,omitempty"`
+	// RewriteQuery rewrites the query into a form better suited to vector search.
+	RewriteQuery bool `json:"rewrite_query,omitempty"`
+}
+
+type VectorStoreSearchContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// VectorStoreSearchResult is a file matching a search, with its chunks matching the query.
+type VectorStoreSearchResult struct {
+	FileID     string                     `json:"file_id"`
+	Filename   string                     `json:"filename"`
+	Score      float64                    `json:"score"`
+	Attributes map[string]any             `json:"attributes"`
+	Content    []VectorStoreSearchContent `json:"content"`
+}
+
+// Text returns the text of the chunks of the result, separated by blank lines.
+func (r VectorStoreSearchResult) Text() string {
+	texts := make([]string, 0, len(r.Content))
+	for _, content := range r.Content {
+		if content.Type == "text" {
+			texts = append(texts, content.Text)
+		}
+	}
+	return strings.Join(texts, "\n\n")
+}
+
+// VectorStoreSearchResponse is a page of search results, best first.
+type VectorStoreSearchResponse struct {
+	Object string `json:"object"`
+	// SearchQuery is the query as searched, rewritten when RewriteQuery is set.
+	SearchQuery []string                  `json:"search_query"`
+	Data        []VectorStoreSearchResult `json:"data"`
+	HasMore     bool                      `json:"has_more"`
+	NextPage    *string                   `json:"next_page"`
+
+	httpHeader
 }
 
 // CreateVectorStore creates a new vector store.
@@ -189,6 +328,14 @@ func (c *Client) ListVectorStores(
 	return
 }
 
+// ListVectorStoresPager returns a Pager over all vector stores.
+func (c *Client) ListVectorStoresPager(ctx context.Context, pagination Pagination) *Pager[VectorStore] {
+	return newPager(ctx, pagination, func(ctx context.Context, page Pagination) ([]VectorStore, bool, string, error) {
+		list, err := c.ListVectorStores(ctx, page)
+		return list.VectorStores, list.HasMore, derefString(list.LastID), err
+	})
+}
+
 // CreateVectorStoreFile creates a new vector store file.
 func (c *Client) CreateVectorStoreFile(
 	ctx context.Context,
@@ -218,6 +365,25 @@ func (c *Client) RetrieveVectorStoreFile(
 	return
 }
 
+// UpdateVectorStoreFileAttributes replaces the attributes of a vector store file.
+func (c *Client) UpdateVectorStoreFileAttributes(
+	ctx context.Context,
+	vectorStoreID string,
+	fileID string,
+	attributes map[string]any,
+) (response VectorStoreFile, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s%s/%s", vectorStoresSuffix, vectorStoreID, vectorStoresFilesSuffix, fileID)
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix),
+		withBody(VectorStoreFileAttributesRequest{Attributes: attributes}),
+		withBetaAssistantVersion(c.config.AssistantVersion))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
 // DeleteVectorStoreFile deletes an existing file.
 func (c *Client) DeleteVectorStoreFile(
 	ctx context.Context,
@@ -265,6 +431,18 @@ func (c *Client) ListVectorStoreFiles(
 	return
 }
 
+// ListVectorStoreFilesPager returns a Pager over all files of a vector store.
+func (c *Client) ListVectorStoreFilesPager(
+	ctx context.Context,
+	vectorStoreID string,
+	pagination Pagination,
+) *Pager[VectorStoreFile] {
+	return newPager(ctx, pagination, func(ctx context.Context, page Pagination) ([]VectorStoreFile, bool, string, error) {
+		list, err := c.ListVectorStoreFiles(ctx, vectorStoreID, page)
+		return list.VectorStoreFiles, list.HasMore, list.LastID, err
+	})
+}
+
 // CreateVectorStoreFileBatch creates a new vector store file batch.
 func (c *Client) CreateVectorStoreFileBatch(
 	ctx context.Context,
@@ -343,3 +521,34 @@ func (c *Client) ListVectorStoreFilesInBatch(
 	err = c.sendRequest(req, &response)
 	return
 }
+
+// ListVectorStoreFilesInBatchPager returns a Pager over all files of a vector store file batch.
+func (c *Client) ListVectorStoreFilesInBatchPager(
+	ctx context.Context,
+	vectorStoreID string,
+	batchID string,
+	pagination Pagination,
+) *Pager[VectorStoreFile] {
+	return newPager(ctx, pagination, func(ctx context.Context, page Pagination) ([]VectorStoreFile, bool, string, error) {
+		list, err := c.ListVectorStoreFilesInBatch(ctx, vectorStoreID, batchID, page)
+		return list.VectorStoreFiles, list.HasMore, list.LastID, err
+	})
+}
+
+// SearchVectorStore searches the chunks of the files of a vector store relevant to a query.
+func (c *Client) SearchVectorStore(
+	ctx context.Context,
+	vectorStoreID string,
+	request VectorStoreSearchRequest,
+) (response VectorStoreSearchResponse, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s%s", vectorStoresSuffix, vectorStoreID, vectorStoresSearchSuffix)
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix),
+		withBody(request),
+		withBetaAssistantVersion(c.config.AssistantVersion))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
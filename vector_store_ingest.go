@@ -0,0 +1,403 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultIngestUploadConcurrency = 4
+	defaultIngestBatchSize         = 500
+	defaultIngestPollInterval      = time.Second
+	// The defaults of the static chunking strategy of the API.
+	defaultIngestMaxChunkSizeTokens = 800
+	defaultIngestChunkOverlapTokens = 400
+	// ingestCleanupTimeout bounds the deletion of the uploaded files that were not added.
+	ingestCleanupTimeout = 30 * time.Second
+)
+
+// VectorStoreIngestFile is a file to add to a vector store.
+type VectorStoreIngestFile struct {
+	// Name is the name of the uploaded file, whose extension tells the API how to parse it. It
+	// defaults to the base name of Path.
+	Name string
+	// Reader is the content of the file. When it is nil, the file at Path is read.
+	Reader io.Reader
+	Path   string
+}
+
+// VectorStoreIngestedFile is the outcome of adding a file to a vector store.
+type VectorStoreIngestedFile struct {
+	Name string
+	Path string
+	// FileID is the ID of the uploaded file, empty if the upload failed. Uploaded files that were not
+	// added to the vector store are deleted, unless the vector store may still be processing them.
+	FileID string
+	// BatchID is the ID of the file batch the file was added with, empty if no batch was created.
+	BatchID string
+	// Status is one of the VectorStoreFileStatus values.
+	Status string
+	// Err is why the file was not added, either the upload error or a *VectorStoreFileError.
+	Err error
+}
+
+// VectorStoreIngestResult holds the outcome of every file, in the order of the files, and the
+// file batches the uploaded files were added with.
+type VectorStoreIngestResult struct {
+	Files   []VectorStoreIngestedFile
+	Batches []VectorStoreFileBatch
+}
+
+// Failed returns the files that were not added to the vector store.
+func (r VectorStoreIngestResult) Failed() []VectorStoreIngestedFile {
+	var failed []VectorStoreIngestedFile
+	for _, file := range r.Files {
+		if file.Status != VectorStoreFileStatusCompleted {
+			failed = append(failed, file)
+		}
+	}
+	return failed
+}
+
+// Err returns the errors of the files that were not added, joined, or nil if all were added.
+func (r VectorStoreIngestResult) Err() error {
+	var errs []error
+	for _, file := range r.Failed() {
+		err := file.Err
+		if err == nil {
+			err = fmt.Errorf("file is %s", file.Status)
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", file.Name, err))
+	}
+	return errors.Join(errs...)
+}
+
+// VectorStoreIngester adds files to a vector store. It uploads the files concurrently, adds them
+// with file batches and waits for the vector store to process them.
+type VectorStoreIngester struct {
+	client *Client
+
+	// ChunkingStrategy is how the files are split into chunks. Setting it to nil lets the API choose
+	// with its auto strategy.
+	ChunkingStrategy *ChunkingStrategy
+	// UploadConcurrency is the maximum number of files uploaded at the same time.
+	UploadConcurrency int
+	// BatchSize is the maximum number of files of a file batch.
+	BatchSize int
+	// PollInterval is the delay between polls of a file batch being processed.
+	PollInterval time.Duration
+}
+
+// NewVectorStoreIngester creates a VectorStoreIngester with a static chunking strategy of 800-token
+// chunks overlapping by 400 tokens.
+func NewVectorStoreIngester(client *Client) *VectorStoreIngester {
+	return &VectorStoreIngester{
+		client:            client,
+		ChunkingStrategy:  NewStaticChunkingStrategy(defaultIngestMaxChunkSizeTokens, defaultIngestChunkOverlapTokens),
+		UploadConcurrency: defaultIngestUploadConcurrency,
+		BatchSize:         defaultIngestBatchSize,
+		PollInterval:      defaultIngestPollInterval,
+	}
+}
+
+// IngestDir adds the regular files of a directory and its subdirectories to a vector store,
+// skipping hidden files and directories.
+func (i *VectorStoreIngester) IngestDir(
+	ctx context.Context,
+	vectorStoreID string,
+	dir string,
+) (VectorStoreIngestResult, error) {
+	var files []VectorStoreIngestFile
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != dir && strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.Type().IsRegular() {
+			files = append(files, VectorStoreIngestFile{Path: path})
+		}
+		return nil
+	})
+	if err != nil {
+		return VectorStoreIngestResult{}, err
+	}
+	return i.Ingest(ctx, vectorStoreID, files)
+}
+
+// Ingest adds files to a vector store. Files that cannot be uploaded or processed are reported in
+// the result, which only fails when the context is done or a file batch cannot be created or polled.
+// If the context is done while a file batch is processed, the batch is cancelled. The files that
+// were uploaded but not added are deleted.
+func (i *VectorStoreIngester) Ingest(
+	ctx context.Context,
+	vectorStoreID string,
+	files []VectorStoreIngestFile,
+) (result VectorStoreIngestResult, err error) {
+	defer func() {
+		i.abandon(ctx, vectorStoreID, result.Files, err)
+	}()
+
+	result = VectorStoreIngestResult{Files: make([]VectorStoreIngestedFile, len(files))}
+	for index, file := range files {
+		name := file.Name
+		if name == "" {
+			name = filepath.Base(file.Path)
+		}
+		result.Files[index] = VectorStoreIngestedFile{Name: name, Path: file.Path}
+	}
+
+	i.uploadFiles(ctx, files, result.Files)
+	if err = ctx.Err(); err != nil {
+		return result, err
+	}
+
+	var uploaded []int
+	for index, file := range result.Files {
+		if file.FileID != "" {
+			uploaded = append(uploaded, index)
+		}
+	}
+	batchSize := i.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultIngestBatchSize
+	}
+	for start := 0; start < len(uploaded); start += batchSize {
+		var batch VectorStoreFileBatch
+		batch, err = i.addBatch(ctx, vectorStoreID, result.Files, uploaded[start:min(start+batchSize, len(uploaded))])
+		if batch.ID != "" {
+			result.Batches = append(result.Batches, batch)
+		}
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// abandon fails the files that were not added because of err, and deletes the uploaded files that
+// were not added. The files of the batches that were stopped early are listed again first, since the
+// vector store may have processed them meanwhile. Files it may still be processing are not deleted.
+// Listing and deletion are best effort, the caller's context may already be done.
+func (i *VectorStoreIngester) abandon(
+	ctx context.Context,
+	vectorStoreID string,
+	files []VectorStoreIngestedFile,
+	err error,
+) {
+	cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), ingestCleanupTimeout)
+	defer cancel()
+
+	var statuses map[string]VectorStoreFile
+	if err != nil {
+		statuses = i.listBatchFiles(cleanupCtx, vectorStoreID, files)
+	}
+	for index := range files {
+		file := &files[index]
+		if file.Status == VectorStoreFileStatusCompleted {
+			continue
+		}
+		if err != nil && file.BatchID != "" {
+			status, listed := statuses[file.FileID]
+			if listed && status.Status == VectorStoreFileStatusCompleted {
+				file.Status, file.Err = status.Status, nil
+				continue
+			}
+			if !listed || status.Status == VectorStoreFileStatusInProgress {
+				file.Status = VectorStoreFileStatusInProgress
+				file.Err = fmt.Errorf("file was not added: %w", err)
+				continue
+			}
+		}
+		if file.Err == nil && err != nil {
+			file.Status = VectorStoreFileStatusFailed
+			file.Err = fmt.Errorf("file was not added: %w", err)
+		}
+		if file.FileID != "" {
+			_ = i.client.DeleteFile(cleanupCtx, file.FileID)
+		}
+	}
+}
+
+// listBatchFiles returns the vector store files of the batches of the files that are not completed,
+// by ID. The files of the batches that cannot be listed are left out.
+func (i *VectorStoreIngester) listBatchFiles(
+	ctx context.Context,
+	vectorStoreID string,
+	files []VectorStoreIngestedFile,
+) map[string]VectorStoreFile {
+	statuses := make(map[string]VectorStoreFile)
+	listed := make(map[string]bool)
+	for _, file := range files {
+		if file.BatchID == "" || file.Status == VectorStoreFileStatusCompleted || listed[file.BatchID] {
+			continue
+		}
+		listed[file.BatchID] = true
+
+		batchStatuses := make(map[string]VectorStoreFile)
+		pager := i.client.ListVectorStoreFilesInBatchPager(ctx, vectorStoreID, file.BatchID, Pagination{})
+		for pager.Next() {
+			current := pager.Current()
+			batchStatuses[current.ID] = current
+		}
+		if pager.Err() == nil {
+			maps.Copy(statuses, batchStatuses)
+		}
+	}
+	return statuses
+}
+
+// uploadFiles uploads the files concurrently, setting the file ID of the uploaded files and the
+// error of the others.
+func (i *VectorStoreIngester) uploadFiles(
+	ctx context.Context,
+	files []VectorStoreIngestFile,
+	results []VectorStoreIngestedFile,
+) {
+	concurrency := i.UploadConcurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for index, file := range files {
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			uploaded, err := i.uploadFile(ctx, results[index].Name, file)
+			if err != nil {
+				results[index].Status = VectorStoreFileStatusFailed
+				results[index].Err = err
+				return
+			}
+			results[index].FileID = uploaded.ID
+			results[index].Status = VectorStoreFileStatusInProgress
+		}()
+	}
+	wg.Wait()
+}
+
+func (i *VectorStoreIngester) uploadFile(ctx context.Context, name string, file VectorStoreIngestFile) (File, error) {
+	reader := file.Reader
+	if reader == nil {
+		f, err := os.Open(file.Path)
+		if err != nil {
+			return File{}, err
+		}
+		defer f.Close()
+		reader = f
+	}
+	return i.client.CreateFileReader(ctx, FileReaderRequest{Name: name, Reader: reader, Purpose: PurposeAssistants})
+}
+
+// addBatch adds the uploaded files at the given indexes with a file batch, waits for the batch to be
+// processed and sets the status of the files.
+func (i *VectorStoreIngester) addBatch(
+	ctx context.Context,
+	vectorStoreID string,
+	files []VectorStoreIngestedFile,
+	indexes []int,
+) (VectorStoreFileBatch, error) {
+	request := VectorStoreFileBatchRequest{FileIDs: make([]string, len(indexes)), ChunkingStrategy: i.ChunkingStrategy}
+	for j, index := range indexes {
+		request.FileIDs[j] = files[index].FileID
+	}
+	batch, err := i.client.CreateVectorStoreFileBatch(ctx, vectorStoreID, request)
+	if err != nil {
+		return batch, err
+	}
+	for _, index := range indexes {
+		files[index].BatchID = batch.ID
+	}
+
+	batch, err = i.waitBatch(ctx, vectorStoreID, batch)
+	if err != nil {
+		// Best effort, the caller's context may already be done.
+		_, _ = i.client.CancelVectorStoreFileBatch(context.WithoutCancel(ctx), vectorStoreID, batch.ID)
+		return batch, err
+	}
+
+	statuses := make(map[string]VectorStoreFile, len(indexes))
+	if batch.FileCounts.Completed != batch.FileCounts.Total {
+		pager := i.client.ListVectorStoreFilesInBatchPager(ctx, vectorStoreID, batch.ID, Pagination{})
+		for pager.Next() {
+			file := pager.Current()
+			statuses[file.ID] = file
+		}
+		if err = pager.Err(); err != nil {
+			return batch, err
+		}
+	}
+
+	for _, index := range indexes {
+		file := &files[index]
+		status, listed := statuses[file.FileID]
+		switch {
+		case !listed && batch.FileCounts.Completed == batch.FileCounts.Total:
+			file.Status = VectorStoreFileStatusCompleted
+		case !listed:
+			file.Status = batch.Status
+			file.Err = fmt.Errorf("file batch %s is %s", batch.ID, batch.Status)
+		case status.Status == VectorStoreFileStatusCompleted:
+			file.Status = status.Status
+		case status.LastError != nil:
+			file.Status = status.Status
+			file.Err = status.LastError
+		default:
+			file.Status = status.Status
+			file.Err = fmt.Errorf("file is %s", status.Status)
+		}
+	}
+	return batch, nil
+}
+
+// waitBatch polls a file batch until it is no longer in progress.
+func (i *VectorStoreIngester) waitBatch(
+	ctx context.Context,
+	vectorStoreID string,
+	batch VectorStoreFileBatch,
+) (VectorStoreFileBatch, error) {
+	interval := i.PollInterval
+	if interval <= 0 {
+		interval = defaultIngestPollInterval
+	}
+	for batch.Status == VectorStoreFileStatusInProgress {
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return batch, ctx.Err()
+		case <-timer.C:
+		}
+
+		polled, err := i.client.RetrieveVectorStoreFileBatch(ctx, vectorStoreID, batch.ID)
+		if err != nil {
+			return batch, err
+		}
+		batch = polled
+	}
+	return batch, nil
+}
@@ -0,0 +1,282 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/neospace-ai/go-openai"
+	"github.com/neospace-ai/go-openai/internal/test"
+	"github.com/neospace-ai/go-openai/internal/test/checks"
+)
+
+// ingestServer emulates file uploads and vector store file batches. Uploads of files named
+// "upload-error*" fail, and files named "broken*" fail to be processed. Batches are in progress
+// until they are polled once, or for ever when hang is set. The files of a hanging batch are in
+// progress until it is cancelled, except for files named "late*", which are completed.
+type ingestServer struct {
+	mu        sync.Mutex
+	uploads   map[string]string
+	purposes  map[string]string
+	deleted   []string
+	batches   []openai.VectorStoreFileBatchRequest
+	polled    map[string]bool
+	cancelled bool
+	hang      bool
+}
+
+func newIngestServer() *ingestServer {
+	return &ingestServer{
+		uploads:  make(map[string]string),
+		purposes: make(map[string]string),
+		polled:   make(map[string]bool),
+	}
+}
+
+func (s *ingestServer) register(t *testing.T, server *test.ServerTest) {
+	server.RegisterHandler("/v1/files", func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("file")
+		checks.NoError(t, err, "FormFile error")
+		data, err := io.ReadAll(file)
+		checks.NoError(t, err, "ReadAll error")
+		if strings.HasPrefix(header.Filename, "upload-error") {
+			http.Error(w, `{"error":{"message":"unsupported file"}}`, http.StatusBadRequest)
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.uploads[header.Filename] = string(data)
+		s.purposes[header.Filename] = r.FormValue("purpose")
+		fmt.Fprintf(w, `{"id":"file-%s","filename":%q}`, header.Filename, header.Filename)
+	})
+	server.RegisterHandler("/v1/files/*", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		id := strings.TrimPrefix(r.URL.Path, "/v1/files/")
+		s.deleted = append(s.deleted, id)
+		fmt.Fprintf(w, `{"id":%q,"deleted":true}`, id)
+	})
+	server.RegisterHandler("/v1/vector_stores/vs_1/file_batches", func(w http.ResponseWriter, r *http.Request) {
+		var request openai.VectorStoreFileBatchRequest
+		checks.NoError(t, json.NewDecoder(r.Body).Decode(&request), "Decode error")
+		s.mu.Lock()
+		s.batches = append(s.batches, request)
+		id := fmt.Sprintf("batch_%d", len(s.batches)-1)
+		s.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(openai.VectorStoreFileBatch{
+			ID:         id,
+			Status:     openai.VectorStoreFileStatusInProgress,
+			FileCounts: openai.VectorStoreFileCount{InProgress: len(request.FileIDs), Total: len(request.FileIDs)},
+		})
+	})
+	server.RegisterHandler("/v1/vector_stores/vs_1/file_batches/batch_*", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		path := strings.TrimPrefix(r.URL.Path, "/v1/vector_stores/vs_1/file_batches/")
+		id, action, _ := strings.Cut(path, "/")
+		var index int
+		fmt.Sscanf(id, "batch_%d", &index)
+		request := s.batches[index]
+
+		switch action {
+		case "cancel":
+			s.cancelled = true
+			fmt.Fprintf(w, `{"id":%q,"status":"cancelled"}`, id)
+		case "files":
+			var list openai.VectorStoreFilesList
+			for _, fileID := range request.FileIDs {
+				file := openai.VectorStoreFile{ID: fileID, Status: openai.VectorStoreFileStatusCompleted}
+				switch {
+				case s.hang && strings.HasPrefix(fileID, "file-late"):
+				case s.hang && s.cancelled:
+					file.Status = openai.VectorStoreFileStatusCancelled
+				case s.hang:
+					file.Status = openai.VectorStoreFileStatusInProgress
+				case strings.HasPrefix(fileID, "file-broken"):
+					file.Status = openai.VectorStoreFileStatusFailed
+					file.LastError = &openai.VectorStoreFileError{Code: "invalid_file", Message: "no text"}
+				}
+				list.VectorStoreFiles = append(list.VectorStoreFiles, file)
+			}
+			_ = json.NewEncoder(w).Encode(list)
+		default:
+			batch := openai.VectorStoreFileBatch{ID: id, Status: openai.VectorStoreFileStatusInProgress}
+			batch.FileCounts.Total = len(request.FileIDs)
+			if s.polled[id] && !s.hang {
+				batch.Status = openai.VectorStoreFileStatusCompleted
+				for _, fileID := range request.FileIDs {
+					if strings.HasPrefix(fileID, "file-broken") {
+						batch.FileCounts.Failed++
+					} else {
+						batch.FileCounts.Completed++
+					}
+				}
+			} else {
+				batch.FileCounts.InProgress = batch.FileCounts.Total
+			}
+			s.polled[id] = true
+			_ = json.NewEncoder(w).Encode(batch)
+		}
+	})
+}
+
+func TestVectorStoreIngesterIngestDir(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	ingest := newIngestServer()
+	ingest.register(t, server)
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.md":            "alpha",
+		"docs/b.txt":      "beta",
+		"docs/broken.pdf": "%PDF",
+		"upload-error.md": "nope",
+		".hidden.md":      "hidden",
+		".git/config":     "ignored",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		checks.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755), "MkdirAll error")
+		checks.NoError(t, os.WriteFile(path, []byte(content), 0o600), "WriteFile error")
+	}
+
+	ingester := openai.NewVectorStoreIngester(client)
+	ingester.ChunkingStrategy = openai.NewStaticChunkingStrategy(400, 100)
+	ingester.BatchSize = 2
+	ingester.PollInterval = time.Millisecond
+	result, err := ingester.IngestDir(context.Background(), "vs_1", dir)
+	checks.NoError(t, err, "IngestDir error")
+
+	want := map[string]string{"a.md": "alpha", "b.txt": "beta", "broken.pdf": "%PDF"}
+	if !reflect.DeepEqual(ingest.uploads, want) {
+		t.Errorf("uploaded %v, want %v", ingest.uploads, want)
+	}
+	for name, purpose := range ingest.purposes {
+		if purpose != string(openai.PurposeAssistants) {
+			t.Errorf("%s uploaded with purpose %q, want assistants", name, purpose)
+		}
+	}
+	if len(ingest.batches) != 2 || len(result.Batches) != 2 {
+		t.Fatalf("created %d batches, result has %d, want 2", len(ingest.batches), len(result.Batches))
+	}
+	for _, batch := range ingest.batches {
+		if batch.ChunkingStrategy == nil || batch.ChunkingStrategy.Type != openai.ChunkingStrategyTypeStatic ||
+			batch.ChunkingStrategy.Static.MaxChunkSizeTokens != 400 || batch.ChunkingStrategy.Static.ChunkOverlapTokens != 100 {
+			t.Errorf("batch chunking strategy = %+v, want static 400/100", batch.ChunkingStrategy)
+		}
+	}
+
+	statuses := make(map[string]string)
+	for _, file := range result.Files {
+		statuses[file.Name] = file.Status
+	}
+	wantStatuses := map[string]string{
+		"a.md":            openai.VectorStoreFileStatusCompleted,
+		"b.txt":           openai.VectorStoreFileStatusCompleted,
+		"broken.pdf":      openai.VectorStoreFileStatusFailed,
+		"upload-error.md": openai.VectorStoreFileStatusFailed,
+	}
+	if !reflect.DeepEqual(statuses, wantStatuses) {
+		t.Errorf("statuses = %v, want %v", statuses, wantStatuses)
+	}
+
+	var failed []string
+	for _, file := range result.Failed() {
+		failed = append(failed, file.Name)
+		if file.Err == nil {
+			t.Errorf("failed file %s has no error", file.Name)
+		}
+	}
+	sort.Strings(failed)
+	if !reflect.DeepEqual(failed, []string{"broken.pdf", "upload-error.md"}) {
+		t.Errorf("failed = %v, want broken.pdf and upload-error.md", failed)
+	}
+	var fileErr *openai.VectorStoreFileError
+	if !errors.As(result.Err(), &fileErr) || fileErr.Code != "invalid_file" {
+		t.Errorf("Err() = %v, want a VectorStoreFileError", result.Err())
+	}
+	if !reflect.DeepEqual(ingest.deleted, []string{"file-broken.pdf"}) {
+		t.Errorf("deleted %v, want the file that failed to be processed", ingest.deleted)
+	}
+}
+
+func TestVectorStoreIngesterReaders(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	ingest := newIngestServer()
+	ingest.register(t, server)
+
+	ingester := openai.NewVectorStoreIngester(client)
+	ingester.PollInterval = time.Millisecond
+	result, err := ingester.Ingest(context.Background(), "vs_1", []openai.VectorStoreIngestFile{
+		{Name: "one.txt", Reader: strings.NewReader("one")},
+		{Name: "two.txt", Reader: strings.NewReader("two")},
+	})
+	checks.NoError(t, err, "Ingest error")
+	checks.NoError(t, result.Err(), "Ingest should add all files")
+	if len(ingest.batches) != 1 || ingest.batches[0].ChunkingStrategy == nil ||
+		*ingest.batches[0].ChunkingStrategy.Static != (openai.StaticChunkingStrategy{
+			MaxChunkSizeTokens: 800, ChunkOverlapTokens: 400,
+		}) {
+		t.Errorf("batches = %+v, want one with the default static chunking strategy", ingest.batches)
+	}
+	if result.Files[1].FileID != "file-two.txt" {
+		t.Errorf("file ID = %q, want file-two.txt", result.Files[1].FileID)
+	}
+}
+
+func TestVectorStoreIngesterCancelsBatch(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	ingest := newIngestServer()
+	ingest.hang = true
+	ingest.register(t, server)
+
+	ingester := openai.NewVectorStoreIngester(client)
+	ingester.PollInterval = time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	result, err := ingester.Ingest(ctx, "vs_1", []openai.VectorStoreIngestFile{
+		{Name: "a.txt", Reader: strings.NewReader("a")},
+		{Name: "b.txt", Reader: strings.NewReader("b")},
+		{Name: "late.txt", Reader: strings.NewReader("late")},
+	})
+	checks.ErrorIs(t, err, context.DeadlineExceeded, "Ingest should fail when the context is done")
+	if !ingest.cancelled {
+		t.Error("the file batch was not cancelled")
+	}
+	for _, file := range result.Files[:2] {
+		if file.Status != openai.VectorStoreFileStatusFailed || !errors.Is(file.Err, context.DeadlineExceeded) {
+			t.Errorf("file %s is %s with error %v, want failed with the context error", file.Name, file.Status, file.Err)
+		}
+	}
+	// The file completed after the batch stopped being polled, and is kept.
+	if late := result.Files[2]; late.Status != openai.VectorStoreFileStatusCompleted || late.Err != nil {
+		t.Errorf("file late.txt is %s with error %v, want completed", late.Status, late.Err)
+	}
+	if len(result.Failed()) != 2 {
+		t.Errorf("failed files %+v, want a.txt and b.txt", result.Failed())
+	}
+	checks.ErrorIs(t, result.Err(), context.DeadlineExceeded, "Err() should report the context error")
+	sort.Strings(ingest.deleted)
+	if !reflect.DeepEqual(ingest.deleted, []string{"file-a.txt", "file-b.txt"}) {
+		t.Errorf("deleted %v, want the uploaded files", ingest.deleted)
+	}
+}
@@ -347,3 +347,84 @@ func TestVectorStore(t *testing.T) {
 		checks.NoError(t, err, "CancelVectorStoreFileBatch error")
 	})
 }
+
+func TestSearchVectorStore(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	server.RegisterHandler("/v1/vector_stores/vs_abc123/search", func(w http.ResponseWriter, r *http.Request) {
+		var request map[string]any
+		checks.NoError(t, json.NewDecoder(r.Body).Decode(&request), "Decode error")
+		got, _ := json.Marshal(request)
+		want := `{"filters":{"filters":[{"key":"year","type":"gte","value":2020},` +
+			`{"filters":[{"key":"draft","type":"eq","value":false},{"key":"team","type":"ne","value":"legal"}],"type":"or"}],` +
+			`"type":"and"},"max_num_results":5,"query":"refund policy",` +
+			`"ranking_options":{"ranker":"auto","score_threshold":0.5},"rewrite_query":true}`
+		if string(got) != want {
+			t.Errorf("request = %s, want %s", got, want)
+		}
+
+		fmt.Fprint(w, `{
+			"object": "vector_store.search_results.page",
+			"search_query": ["refund policy"],
+			"data": [{
+				"file_id": "file-1",
+				"filename": "policy.md",
+				"score": 0.92,
+				"attributes": {"year": 2024, "draft": false},
+				"content": [{"type": "text", "text": "Refunds within 30 days."}, {"type": "text", "text": "Keep the receipt."}]
+			}],
+			"has_more": false,
+			"next_page": null
+		}`)
+	})
+
+	threshold := 0.5
+	response, err := client.SearchVectorStore(context.Background(), "vs_abc123", openai.VectorStoreSearchRequest{
+		Query: "refund policy",
+		Filters: &openai.VectorStoreFilter{
+			Type: openai.VectorStoreFilterTypeAnd,
+			Filters: []openai.VectorStoreFilter{
+				openai.VectorStoreFilterCompare("year", openai.VectorStoreFilterTypeGreaterThanOrEqual, 2020),
+				openai.VectorStoreFilterOr(
+					openai.VectorStoreFilterCompare("draft", openai.VectorStoreFilterTypeEqual, false),
+					openai.VectorStoreFilterCompare("team", openai.VectorStoreFilterTypeNotEqual, "legal"),
+				),
+			},
+		},
+		MaxNumResults:  5,
+		RankingOptions: &openai.VectorStoreRankingOptions{Ranker: openai.VectorStoreRankerAuto, ScoreThreshold: &threshold},
+		RewriteQuery:   true,
+	})
+	checks.NoError(t, err, "SearchVectorStore error")
+
+	if len(response.SearchQuery) != 1 || len(response.Data) != 1 || response.NextPage != nil {
+		t.Fatalf("response = %+v, want one query and one result", response)
+	}
+	result := response.Data[0]
+	if result.FileID != "file-1" || result.Score != 0.92 || result.Attributes["year"] != 2024.0 {
+		t.Errorf("result = %+v, want file-1 with score 0.92 and year 2024", result)
+	}
+	if got := result.Text(); got != "Refunds within 30 days.\n\nKeep the receipt." {
+		t.Errorf("Text() = %q", got)
+	}
+}
+
+func TestUpdateVectorStoreFileAttributes(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	server.RegisterHandler("/v1/vector_stores/vs_abc123/files/file-1", func(w http.ResponseWriter, r *http.Request) {
+		var request openai.VectorStoreFileAttributesRequest
+		checks.NoError(t, json.NewDecoder(r.Body).Decode(&request), "Decode error")
+		response := openai.VectorStoreFile{ID: "file-1", Attributes: request.Attributes}
+		_ = json.NewEncoder(w).Encode(response)
+	})
+
+	file, err := client.UpdateVectorStoreFileAttributes(context.Background(), "vs_abc123", "file-1",
+		map[string]any{"team": "support", "year": 2024})
+	checks.NoError(t, err, "UpdateVectorStoreFileAttributes error")
+	if file.Attributes["team"] != "support" || file.Attributes["year"] != 2024.0 {
+		t.Errorf("attributes = %v, want team support and year 2024", file.Attributes)
+	}
+}
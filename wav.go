@@ -0,0 +1,145 @@
+package openai
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	wavFormatPCM        = 1
+	wavFormatFloat      = 3
+	wavFormatExtensible = 0xFFFE
+	wavHeaderSize       = 44
+)
+
+var ErrUnsupportedAudio = errors.New("unsupported audio format")
+
+// PCMFormat describes uncompressed audio: interleaved little-endian samples, which are unsigned
+// for 8 bits, signed integers for more bits, or floats when Float is set.
+type PCMFormat struct {
+	SampleRate    int
+	Channels      int
+	BitsPerSample int
+	Float         bool
+}
+
+func (f PCMFormat) validate() error {
+	if f.SampleRate <= 0 || f.Channels <= 0 {
+		return fmt.Errorf("%w: %d channels at %d Hz", ErrUnsupportedAudio, f.Channels, f.SampleRate)
+	}
+	switch {
+	case f.Float && (f.BitsPerSample == 32 || f.BitsPerSample == 64):
+	case !f.Float && (f.BitsPerSample == 8 || f.BitsPerSample == 16 || f.BitsPerSample == 24 || f.BitsPerSample == 32):
+	default:
+		return fmt.Errorf("%w: %d bits per sample", ErrUnsupportedAudio, f.BitsPerSample)
+	}
+	return nil
+}
+
+// FrameSize returns the size in bytes of a sample of every channel.
+func (f PCMFormat) FrameSize() int {
+	return f.Channels * f.BitsPerSample / 8
+}
+
+// Duration returns the duration of the given number of bytes of audio.
+func (f PCMFormat) Duration(size int) time.Duration {
+	frames := size / f.FrameSize()
+	return time.Duration(frames) * time.Second / time.Duration(f.SampleRate)
+}
+
+// sample returns the sample of a channel in a frame, between -1 and 1.
+func (f PCMFormat) sample(frame []byte, channel int) float64 {
+	b := frame[channel*f.BitsPerSample/8:]
+	switch {
+	case f.Float && f.BitsPerSample == 32:
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(b)))
+	case f.Float:
+		return math.Float64frombits(binary.LittleEndian.Uint64(b))
+	case f.BitsPerSample == 8:
+		return (float64(b[0]) - 128) / 128
+	case f.BitsPerSample == 16:
+		return float64(int16(binary.LittleEndian.Uint16(b))) / (1 << 15)
+	case f.BitsPerSample == 24:
+		return float64(int32(uint32(b[0])<<8|uint32(b[1])<<16|uint32(b[2])<<24)>>8) / (1 << 23)
+	default:
+		return float64(int32(binary.LittleEndian.Uint32(b))) / (1 << 31)
+	}
+}
+
+// parseWAV returns the format and the samples of a WAV file. The samples are a slice of data.
+func parseWAV(data []byte) (format PCMFormat, samples []byte, err error) {
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		return format, nil, fmt.Errorf("%w: not a WAV file", ErrUnsupportedAudio)
+	}
+
+	hasFormat := false
+	for pos := 12; pos+8 <= len(data); {
+		id := string(data[pos : pos+4])
+		size := int(binary.LittleEndian.Uint32(data[pos+4:]))
+		body := data[pos+8:]
+
+		switch id {
+		case "fmt ":
+			if size < 16 || len(body) < 16 {
+				return format, nil, fmt.Errorf("%w: truncated fmt chunk", ErrUnsupportedAudio)
+			}
+			tag := binary.LittleEndian.Uint16(body)
+			if tag == wavFormatExtensible && size >= 26 && len(body) >= 26 {
+				tag = binary.LittleEndian.Uint16(body[24:])
+			}
+			if tag != wavFormatPCM && tag != wavFormatFloat {
+				return format, nil, fmt.Errorf("%w: WAV format %#x", ErrUnsupportedAudio, tag)
+			}
+			format = PCMFormat{
+				SampleRate:    int(binary.LittleEndian.Uint32(body[4:])),
+				Channels:      int(binary.LittleEndian.Uint16(body[2:])),
+				BitsPerSample: int(binary.LittleEndian.Uint16(body[14:])),
+				Float:         tag == wavFormatFloat,
+			}
+			if err = format.validate(); err != nil {
+				return format, nil, err
+			}
+			hasFormat = true
+		case "data":
+			if !hasFormat {
+				return format, nil, fmt.Errorf("%w: data chunk before fmt chunk", ErrUnsupportedAudio)
+			}
+			// Streamed WAV files may not know their size, and leave it zero or at its maximum.
+			if size == 0 || size > len(body) {
+				size = len(body)
+			}
+			return format, body[:size-size%format.FrameSize()], nil
+		}
+
+		if size > len(body) {
+			break
+		}
+		pos += 8 + size + size%2
+	}
+	return format, nil, fmt.Errorf("%w: no data chunk", ErrUnsupportedAudio)
+}
+
+// appendWAVHeader appends the header of a WAV file holding size bytes of samples.
+func appendWAVHeader(dst []byte, format PCMFormat, size int) []byte {
+	tag := uint16(wavFormatPCM)
+	if format.Float {
+		tag = wavFormatFloat
+	}
+	frameSize := format.FrameSize()
+
+	dst = append(dst, "RIFF"...)
+	dst = binary.LittleEndian.AppendUint32(dst, uint32(wavHeaderSize-8+size))
+	dst = append(dst, "WAVEfmt "...)
+	dst = binary.LittleEndian.AppendUint32(dst, 16)
+	dst = binary.LittleEndian.AppendUint16(dst, tag)
+	dst = binary.LittleEndian.AppendUint16(dst, uint16(format.Channels))
+	dst = binary.LittleEndian.AppendUint32(dst, uint32(format.SampleRate))
+	dst = binary.LittleEndian.AppendUint32(dst, uint32(format.SampleRate*frameSize))
+	dst = binary.LittleEndian.AppendUint16(dst, uint16(frameSize))
+	dst = binary.LittleEndian.AppendUint16(dst, uint16(format.BitsPerSample))
+	dst = append(dst, "data"...)
+	return binary.LittleEndian.AppendUint32(dst, uint32(size))
+}